@@ -3,21 +3,38 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/kekim-go/Author/app/ctx"
 	"github.com/kekim-go/Author/constant"
 	"github.com/kekim-go/Author/database"
+	"github.com/kekim-go/Author/federation"
 	server "github.com/kekim-go/Author/grpc"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/mailer"
+	"github.com/kekim-go/Author/metrics"
+	"github.com/kekim-go/Author/migration"
 	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/oidc"
+	"github.com/kekim-go/Author/policy"
+	"github.com/kekim-go/Author/ratelimit"
+	"github.com/kekim-go/Author/tracing"
+	"github.com/kekim-go/Author/web"
+	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 	"xorm.io/xorm"
 	"xorm.io/xorm/log"
 
-	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 // Application define a mode of running app
@@ -25,12 +42,81 @@ type Application struct {
 	Ctx     *ctx.Context
 	Context context.Context
 	server  *server.Server
+	web     *web.Server
+	tracing func()
+	logFile io.Closer
+	cron    *cron.Cron
 }
 
 // New constructor
 func New(context context.Context) (*Application, error) {
-	var err error
+	a, err := newApplication(context)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Ctx.PasswordPolicy, err = policy.NewPasswordPolicy(a.Ctx.Config.PasswordPolicy); err != nil {
+		return nil, err
+	}
+
+	if a.Ctx.PasswordHasher, err = policy.NewPasswordHasher(a.Ctx.Config.PasswordHash); err != nil {
+		return nil, err
+	}
+
+	if err = a.initMailer(); err != nil {
+		return nil, err
+	}
+
+	if err = a.initOidc(); err != nil {
+		return nil, err
+	}
+	a.Ctx.Federation = federation.NewVerifier(a.Ctx.Config.Federation, nil)
+
+	a.Ctx.Logger.Debug(fmt.Sprintf("Run author service in '%s' mode", a.Ctx.Mode))
+
+	if a.tracing, err = tracing.Init(a.Ctx.Config.Tracing); err != nil {
+		return nil, err
+	}
+
+	if err = a.initDB(); err != nil {
+		return nil, err
+	}
+
+	if err = a.initRedis(); err != nil {
+		return nil, err
+	}
+
+	if err = metrics.RegisterPoolCollector(a.Ctx.Orm, a.Ctx.RedisDB); err != nil {
+		return nil, err
+	}
+
+	if err = a.initCron(); err != nil {
+		return nil, err
+	}
 
+	return a, nil
+}
+
+// NewMigrator migrate 명령용으로 설정, 로그, DB 연결만 초기화하며 반환된 함수로 연결을 닫음
+func NewMigrator(context context.Context) (*migration.Migrator, func(), error) {
+	a, err := newApplication(context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = a.openDB(); err != nil {
+		a.logFile.Close()
+		return nil, nil, err
+	}
+
+	return migration.New(a.Ctx.Orm, a.Ctx.Logger), func() {
+		a.Ctx.Orm.Close()
+		a.logFile.Close()
+	}, nil
+}
+
+// 실행 모드 판별 후 설정 파일과 logger 초기화
+func newApplication(context context.Context) (*Application, error) {
 	a := new(Application)
 	a.Ctx = new(ctx.Context)
 	a.Context = context
@@ -47,32 +133,99 @@ func New(context context.Context) (*Application, error) {
 	a.Ctx.DBConfigFileName = fmt.Sprintf("config/%s/database.yaml", a.Ctx.Mode)
 	a.Ctx.RedisConfigFileName = fmt.Sprintf("config/%s/redis.yaml", a.Ctx.Mode)
 
-	if err = a.initConfig(); err != nil {
-		return nil, err
-	}
-
-	if err = a.initLogger(); err != nil {
+	if err := a.initConfig(); err != nil {
 		return nil, err
 	}
 
-	a.Ctx.Logger.Debug(fmt.Sprintf("Run author service in '%s' mode", a.Ctx.Mode))
-
-	if err = a.initDB(); err != nil {
+	if err := a.initLogger(); err != nil {
 		return nil, err
 	}
 
-	a.initRedis(a.Context)
-
 	return a, nil
 }
 
 // Run starts application
 func (a *Application) Run(network, addr string) {
+	var wg sync.WaitGroup
+
+	if port := a.Ctx.Config.ServerConfig.HttpPort; port > 0 {
+		a.web = web.New(a.Ctx, a.Context)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.web.Run(fmt.Sprintf(":%d", port)); err != nil {
+				a.Ctx.Logger.Info("HTTP Service Run failed")
+				a.Ctx.Logger.Info(err.Error())
+			}
+		}()
+	}
+
+	if port := a.Ctx.Config.ServerConfig.AdminPort; port > 0 {
+		admin := web.NewAdmin(a.Ctx, a.Context)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := admin.Run(fmt.Sprintf(":%d", port)); err != nil {
+				a.Ctx.Logger.Info("Admin HTTP Service Run failed")
+				a.Ctx.Logger.Info(err.Error())
+			}
+		}()
+	}
+
+	a.cron.Start()
+
 	a.server = server.New(a.Ctx, a.Context)
 	if err := a.server.Run(network, addr); err != nil {
 		a.Ctx.Logger.Info("Service Run failed")
 		a.Ctx.Logger.Info(err.Error())
 	}
+
+	// 종료 신호로 gRPC 서버가 종료된 경우 HTTP 서버의 종료도 기다린 후 연결 해제
+	wg.Wait()
+	a.close()
+}
+
+func (a *Application) close() {
+	// 실행 중인 작업은 종료 신호로 취소되므로 끝날 때까지 기다린 후 연결 해제
+	<-a.cron.Stop().Done()
+
+	// 대기 중인 span을 먼저 내보냄
+	a.tracing()
+
+	if err := a.Ctx.Orm.Close(); err != nil {
+		a.Ctx.Logger.Info(err.Error())
+	}
+	if err := a.Ctx.RedisDB.Close(); err != nil {
+		a.Ctx.Logger.Info(err.Error())
+	}
+	a.logFile.Close()
+}
+
+// 주기 작업 등록, 여러 인스턴스에서 같은 작업이 실행되어도 결과가 같도록 작성
+func (a *Application) initCron() error {
+	a.cron = cron.New()
+
+	config := a.Ctx.Config.Purge
+	if len(config.Schedule) == 0 {
+		return nil
+	}
+
+	appHandler := handler.NewAppHandler(a.Ctx)
+	_, err := a.cron.AddFunc(config.Schedule, func() {
+		logger := a.Ctx.Logger.WithField("module", "purge")
+		before := time.Now().Add(-config.GetAppRetention())
+
+		purged, err := appHandler.Purge(a.Context, before)
+		if err != nil {
+			logger.Warn(err)
+		}
+		logger.WithFields(logrus.Fields{"before": before, "apps": purged}).Info("purge deleted apps")
+	})
+	if err != nil {
+		return fmt.Errorf("purge schedule: %w", err)
+	}
+
+	return nil
 }
 
 func (a *Application) initConfig() error {
@@ -105,99 +258,180 @@ func (a *Application) initConfig() error {
 	return nil
 }
 
-func (a *Application) initDB() error {
-	var err error
-
-	dbConfig := a.Ctx.DBConfig
-	a.Ctx.Logger.Info("DB Config ==========================================")
-	a.Ctx.Logger.Info(dbConfig)
-	connectURL := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8&parseTime=True",
-		dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DBName)
-	a.Ctx.Logger.Info(connectURL)
-
-	if a.Ctx.Orm, err = xorm.NewEngine(dbConfig.DBType, connectURL); err != nil {
-		return err
-	}
-
-	if a.Ctx.Mode == constant.ServiceDev {
-		a.Ctx.Orm.ShowSQL(true)
-		a.Ctx.Orm.Logger().SetLevel(log.LOG_DEBUG)
+// 메일 서버 없이 실행하면 확인, 재설정 메일이 발송되지 않으므로 dev 모드에서만 허용
+func (a *Application) initMailer() error {
+	config := a.Ctx.Config.Mailer
+	if len(config.Host) == 0 && a.Ctx.Mode != constant.ServiceDev {
+		return fmt.Errorf("mailer.host is required in %s mode", a.Ctx.Mode)
 	}
-	a.Ctx.Orm.SetMaxIdleConns(dbConfig.IdleConns)
-	a.Ctx.Orm.SetMaxOpenConns(dbConfig.MaxOpenConns)
 
-	//migrate
-	err = a.migrateDB()
+	a.Ctx.Mailer = mailer.New(config, a.Ctx.Logger)
 
-	return err
+	return nil
 }
 
-func (a *Application) migrateDB() error {
-	var err error
-	if err = a.Ctx.Orm.Sync2(new(model.App)); err != nil {
-		return err
+// 임시 키로 서명하면 재시작시 발급한 ID 토큰을 검증할 수 없으므로 dev 모드에서만 허용
+func (a *Application) initOidc() error {
+	config := a.Ctx.Config.Oidc
+	if config.AllowEphemeralKey && a.Ctx.Mode != constant.ServiceDev {
+		return fmt.Errorf("oidc.allowEphemeralKey is only allowed in %s mode", constant.ServiceDev)
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.Token)); err != nil {
+
+	provider, err := oidc.NewProvider(config)
+	if err != nil {
 		return err
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.AppToken)); err != nil {
-		return err
+
+	if provider.Ephemeral {
+		a.Ctx.Logger.Warn("oidc.privateKeyFile is not set; ID tokens are signed with a temporary key")
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.AppTokenHistory)); err != nil {
+	a.Ctx.Oidc = provider
+
+	return nil
+}
+
+func (a *Application) initDB() error {
+	if err := a.openDB(); err != nil {
 		return err
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.Operation)); err != nil {
-		return err
+
+	migrator := migration.New(a.Ctx.Orm, a.Ctx.Logger)
+	if a.Ctx.DBConfig.AutoMigrate {
+		return migrator.Up(a.Context, 0)
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.Traffic)); err != nil {
+
+	pending, err := migrator.Pending(a.Context)
+	if err != nil {
 		return err
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.Group)); err != nil {
-		return err
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is outdated (%d pending migrations), run 'migrate up' or set autoMigrate", len(pending))
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.User)); err != nil {
+
+	return nil
+}
+
+func (a *Application) openDB() error {
+	var err error
+
+	dbConfig := a.Ctx.DBConfig
+	a.Ctx.Logger.WithFields(logrus.Fields{
+		"dbType": dbConfig.DBType,
+		"host":   dbConfig.Host,
+		"port":   dbConfig.Port,
+		"dbName": dbConfig.DBName,
+		"user":   dbConfig.User,
+	}).Info("connect database")
+	dsn, err := dbConfig.DSN()
+	if err != nil {
 		return err
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.Role)); err != nil {
+
+	if a.Ctx.Orm, err = xorm.NewEngine(dbConfig.DBType, dsn); err != nil {
 		return err
 	}
-	if err = a.Ctx.Orm.Sync2(new(model.UserRole)); err != nil {
-		return err
+
+	a.Ctx.Orm.AddHook(tracing.NewXormHook(dbConfig.DBType))
+
+	if a.Ctx.Mode == constant.ServiceDev {
+		a.Ctx.Orm.ShowSQL(true)
+		a.Ctx.Orm.Logger().SetLevel(log.LOG_DEBUG)
 	}
+	a.Ctx.Orm.SetMaxIdleConns(dbConfig.IdleConns)
+	a.Ctx.Orm.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	model.SetQueryTimeout(dbConfig.GetQueryTimeout())
 
 	return nil
 }
 
-func (a *Application) initRedis(context context.Context) {
+func (a *Application) initRedis() error {
 	redisConfig := a.Ctx.RedisConfig
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", redisConfig.Addr, redisConfig.Port),
-		Password:     redisConfig.Password,
-		DB:           redisConfig.DB,
-		MinIdleConns: redisConfig.MinIdleConns,
-		PoolSize:     redisConfig.PoolSize,
+	redisClient, err := newRedisClient(redisConfig)
+	if err != nil {
+		return err
+	}
+
+	logger := a.Ctx.Logger.WithField("module", "redis")
+	breaker := database.NewCircuitBreaker(redisConfig.GetBreakerThreshold(), redisConfig.GetBreakerCooldown(), func(open bool) {
+		if open {
+			metrics.RedisCircuitOpen.Set(1)
+			logger.Warn("redis unavailable, degraded mode activated")
+		} else {
+			metrics.RedisCircuitOpen.Set(0)
+			logger.Info("redis recovered, degraded mode deactivated")
+		}
 	})
+	// 차단된 명령은 tracing hook까지 전달되지 않도록 차단기를 먼저 등록
+	redisClient.AddHook(breaker)
+	redisClient.AddHook(tracing.RedisHook{})
+
+	a.Ctx.RedisDB = database.NewRedisDB(redisClient, redisConfig.GetTimeout(), breaker)
+	a.Ctx.LocalLimiter = ratelimit.NewLocalLimiter()
+
+	// hash tag 적용 전 형식의 트래픽 카운터를 옮겨 재시작으로 사용량이 초기화되지 않도록 함
+	// 이전 형식은 standalone 모드에서만 사용했으므로 cluster 모드는 제외
+	if redisConfig.Mode != ctx.RedisCluster {
+		migrated, err := model.MigrateTokenTrafficKeys(a.Context, a.Ctx.RedisDB)
+		if err != nil {
+			logger.WithError(err).Warn("failed to migrate legacy traffic counters")
+		} else if migrated > 0 {
+			logger.Info(fmt.Sprintf("migrated %d legacy traffic counters", migrated))
+		}
+	}
 
-	a.Ctx.RedisDB = database.NewRedisDB(context, redisClient)
+	return nil
 }
 
-func (a *Application) initLogger() error {
-	logger := logrus.New()
-
-	if _, err := os.Stat("log"); os.IsNotExist(err) {
-		os.Mkdir("log", 0777)
+// newRedisClient 설정된 모드(standalone, sentinel, cluster)에 맞는 client 생성
+func newRedisClient(config *ctx.RedisConfig) (redis.UniversalClient, error) {
+	switch config.Mode {
+	case "", ctx.RedisStandalone:
+		return redis.NewClient(&redis.Options{
+			Addr:         fmt.Sprintf("%s:%d", config.Addr, config.Port),
+			Password:     config.Password,
+			DB:           config.DB,
+			MinIdleConns: config.MinIdleConns,
+			PoolSize:     config.PoolSize,
+		}), nil
+	case ctx.RedisSentinel:
+		if len(config.MasterName) == 0 || len(config.Addrs) == 0 {
+			return nil, fmt.Errorf("redis sentinel mode requires masterName and addrs")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       config.MasterName,
+			SentinelAddrs:    config.Addrs,
+			SentinelPassword: config.SentinelPassword,
+			Password:         config.Password,
+			DB:               config.DB,
+			MinIdleConns:     config.MinIdleConns,
+			PoolSize:         config.PoolSize,
+		}), nil
+	case ctx.RedisCluster:
+		if len(config.Addrs) == 0 {
+			return nil, fmt.Errorf("redis cluster mode requires addrs")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        config.Addrs,
+			Password:     config.Password,
+			MinIdleConns: config.MinIdleConns,
+			PoolSize:     config.PoolSize,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode: %s", config.Mode)
 	}
+}
 
+func (a *Application) initLogger() error {
+	level := logrus.InfoLevel
 	if a.Ctx.Mode == constant.ServiceDev {
-		logger.SetLevel(logrus.DebugLevel)
-	} else {
-		logger.SetLevel(logrus.InfoLevel)
+		level = logrus.DebugLevel
 	}
 
-	// logger.SetFormatter(&logrus.JSONFormatter{})
-	logger.Out = os.Stdout
-	//file, _ := os.OpenFile(a.Ctx.Config.LoggerConfig.FileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0755)
-	//logger.Out = file
+	logger, closer, err := logging.New(a.Ctx.Config.LoggerConfig, level)
+	if err != nil {
+		return err
+	}
+	a.logFile = closer
 
 	a.Ctx.Logger = logger.WithFields(logrus.Fields{
 		"tag": a.Ctx.Config.LoggerConfig.Tag,
@@ -1,7 +1,20 @@
 package ctx
 
 import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/kekim-go/Author/constant"
 	"github.com/kekim-go/Author/database"
+	"github.com/kekim-go/Author/federation"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/mailer"
+	"github.com/kekim-go/Author/oidc"
+	"github.com/kekim-go/Author/policy"
+	"github.com/kekim-go/Author/ratelimit"
+	"github.com/kekim-go/Author/tracing"
 	"github.com/sirupsen/logrus"
 	"xorm.io/xorm"
 )
@@ -16,36 +29,196 @@ type Context struct {
 	DBConfigFileName    string
 	RedisConfig         *RedisConfig
 	RedisConfigFileName string
+	PasswordPolicy      *policy.PasswordPolicy
+	PasswordHasher      *policy.PasswordHasher
+	Oidc                *oidc.Provider
+	Federation          *federation.Verifier
+	Mailer              mailer.Mailer
+	LocalLimiter        *ratelimit.LocalLimiter // Redis 장애 시 사용하는 인스턴스 단위 트래픽 제한
 }
 
 type Config struct {
-	LoggerConfig LoggerConfig `yaml:"logger"`
+	ServerConfig   ServerConfig                `yaml:"server"`
+	LoggerConfig   logging.Config              `yaml:"logger"`
+	PasswordPolicy policy.PasswordPolicyConfig `yaml:"passwordPolicy"`
+	PasswordHash   policy.PasswordHashConfig   `yaml:"passwordHash"`
+	MfaConfig      MfaConfig                   `yaml:"mfa"`
+	Oidc           oidc.Config                 `yaml:"oidc"`
+	Federation     federation.Config           `yaml:"federation"`
+	Mailer         mailer.Config               `yaml:"mailer"`
+	ExtAuthz       ExtAuthzConfig              `yaml:"extAuthz"`
+	Tracing        tracing.Config              `yaml:"tracing"`
+	Purge          PurgeConfig                 `yaml:"purge"`
+}
+
+type ServerConfig struct {
+	Port            int  `yaml:"port"`            // gRPC 서비스 포트, -port 옵션이 우선
+	HttpPort        int  `yaml:"httpPort"`        // OAuth2 등 HTTP 엔드포인트 포트, 0이면 HTTP 서버를 실행하지 않음
+	AdminPort       int  `yaml:"adminPort"`       // Prometheus /metrics 포트, 0이면 실행하지 않음
+	ShutdownTimeout int  `yaml:"shutdownTimeout"` // 종료 신호 수신 후 처리 중인 요청을 기다리는 최대 시간(초)
+	Reflection      bool `yaml:"reflection"`      // gRPC server reflection 사용 여부 (디버깅용)
+}
+
+// GetShutdownTimeout 설정이 없으면 constant.DefaultShutdownTimeout 사용
+func (c ServerConfig) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return constant.DefaultShutdownTimeout
+	}
+
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+type MfaConfig struct {
+	Issuer string `yaml:"issuer"` // 인증 앱에 표시될 서비스 이름
+}
+
+// PurgeConfig : 삭제된 App 영구 삭제 작업 설정
+type PurgeConfig struct {
+	Schedule         string `yaml:"schedule"`         // cron 형식(분 시 일 월 요일) 실행 주기, 비어 있으면 실행하지 않음
+	AppRetentionDays int    `yaml:"appRetentionDays"` // 삭제 후 복구할 수 있는 기간(일)
 }
 
-type LoggerConfig struct {
-	Mode     string `yaml:"mode"`
-	Tag      string `yaml:"tag"`
-	FileName string `yaml:"fileName"`
-	Id       string
+// GetAppRetention 설정이 없으면 constant.DefaultAppRetention 사용
+func (c PurgeConfig) GetAppRetention() time.Duration {
+	if c.AppRetentionDays <= 0 {
+		return constant.DefaultAppRetention
+	}
+
+	return time.Duration(c.AppRetentionDays) * 24 * time.Hour
+}
+
+// ExtAuthzConfig : Envoy ext_authz, nginx auth_request 연동 설정
+type ExtAuthzConfig struct {
+	TokenHeader     string `yaml:"tokenHeader"`     // API 키 헤더, 기본값 x-api-key
+	TokenQueryParam string `yaml:"tokenQueryParam"` // 헤더가 없을 때 사용할 쿼리 파라미터, 기본값 serviceKey
+	NamespaceFrom   string `yaml:"namespaceFrom"`   // path: /{namespace}/{operation}, host: {namespace}.도메인/{operation}
+	PathPrefix      string `yaml:"pathPrefix"`      // namespace 추출 전 제거할 경로 접두어 (예: /api)
 }
 
+const (
+	DBMysql    = "mysql"
+	DBPostgres = "postgres"
+	DBSqlite   = "sqlite3"
+)
+
 // DBConfig : Database Config
 type DBConfig struct {
-	DBName       string `yaml:"dbName"`
-	DBType       string `yaml:"dbType"`
+	DBName       string `yaml:"dbName"` // sqlite3는 DB 파일 경로 (:memory: 가능)
+	DBType       string `yaml:"dbType"` // mysql, postgres, sqlite3
 	Host         string `yaml:"host"`
 	Port         int    `yaml:"port"`
 	User         string `yaml:"user"`
 	Password     string `yaml:"password"`
 	IdleConns    int    `yaml:"idleConns"`
 	MaxOpenConns int    `yaml:"maxOpenConns"`
+
+	QueryTimeout time.Duration `yaml:"queryTimeout"` // 모델 함수별 DB 호출 제한 시간 (예: 3s)
+	AutoMigrate  bool          `yaml:"autoMigrate"`  // 기동시 적용되지 않은 migration 실행, false이면 migrate 명령으로 적용
+
+	Params map[string]string `yaml:"params"` // 드라이버별 추가 접속 옵션 (예: postgres의 sslmode)
 }
 
+// DSN DBType에 맞는 드라이버 접속 문자열
+func (c DBConfig) DSN() (string, error) {
+	switch c.DBType {
+	case DBMysql:
+		config := mysql.NewConfig()
+		config.User = c.User
+		config.Passwd = c.Password
+		config.Net = "tcp"
+		config.Addr = fmt.Sprintf("%s:%d", c.Host, c.Port)
+		config.DBName = c.DBName
+		config.ParseTime = true
+		config.Params = map[string]string{"charset": "utf8"}
+		for key, value := range c.Params {
+			config.Params[key] = value
+		}
+		return config.FormatDSN(), nil
+	case DBPostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.User, c.Password),
+			Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+			Path:     "/" + c.DBName,
+			RawQuery: c.query().Encode(),
+		}
+		return dsn.String(), nil
+	case DBSqlite:
+		if len(c.Params) == 0 {
+			return c.DBName, nil
+		}
+		return fmt.Sprintf("file:%s?%s", c.DBName, c.query().Encode()), nil
+	default:
+		return "", fmt.Errorf("unsupported dbType: %s", c.DBType)
+	}
+}
+
+func (c DBConfig) query() url.Values {
+	query := url.Values{}
+	for key, value := range c.Params {
+		query.Set(key, value)
+	}
+
+	return query
+}
+
+// GetQueryTimeout 설정이 없으면 constant.DefaultQueryTimeout 사용
+func (c DBConfig) GetQueryTimeout() time.Duration {
+	if c.QueryTimeout <= 0 {
+		return constant.DefaultQueryTimeout
+	}
+
+	return c.QueryTimeout
+}
+
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
 type RedisConfig struct {
-	Addr         string `yaml:"addr"`
+	Mode         string `yaml:"mode"` // standalone(기본값), sentinel, cluster
+	Addr         string `yaml:"addr"` // standalone 모드의 주소
 	Port         int    `yaml:"port"`
 	Password     string `yaml:"password"`
-	DB           int    `yaml:"db"`
+	DB           int    `yaml:"db"` // cluster 모드에서는 사용하지 않음
 	MinIdleConns int    `yaml:"minIdleConns"`
 	PoolSize     int    `yaml:"poolSize"`
+
+	Addrs            []string `yaml:"addrs"`            // sentinel 주소 또는 cluster 시드 노드 주소 (host:port)
+	MasterName       string   `yaml:"masterName"`       // sentinel 모드의 master 이름
+	SentinelPassword string   `yaml:"sentinelPassword"` // sentinel 인증 비밀번호
+
+	Timeout time.Duration `yaml:"timeout"` // 명령별 제한 시간 (예: 500ms)
+
+	BreakerThreshold int           `yaml:"breakerThreshold"` // 연속 장애 오류가 이 횟수에 도달하면 Redis 호출 차단
+	BreakerCooldown  time.Duration `yaml:"breakerCooldown"`  // 차단 후 다시 시도하기까지의 대기 시간
+}
+
+// GetTimeout 설정이 없으면 constant.DefaultRedisTimeout 사용
+func (c RedisConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return constant.DefaultRedisTimeout
+	}
+
+	return c.Timeout
+}
+
+// GetBreakerThreshold 설정이 없으면 constant.DefaultRedisBreakerThreshold 사용
+func (c RedisConfig) GetBreakerThreshold() int {
+	if c.BreakerThreshold <= 0 {
+		return constant.DefaultRedisBreakerThreshold
+	}
+
+	return c.BreakerThreshold
+}
+
+// GetBreakerCooldown 설정이 없으면 constant.DefaultRedisBreakerCooldown 사용
+func (c RedisConfig) GetBreakerCooldown() time.Duration {
+	if c.BreakerCooldown <= 0 {
+		return constant.DefaultRedisBreakerCooldown
+	}
+
+	return c.BreakerCooldown
 }
@@ -0,0 +1,58 @@
+package ctx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDBConfigDSN(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DBConfig
+		want   string
+	}{
+		{
+			"mysql",
+			DBConfig{DBType: DBMysql, Host: "db", Port: 3306, User: "author", Password: "p@ss", DBName: "author"},
+			"author:p@ss@tcp(db:3306)/author?parseTime=true&charset=utf8",
+		},
+		{
+			"postgres",
+			DBConfig{DBType: DBPostgres, Host: "db", Port: 5432, User: "author", Password: "p@ss/word", DBName: "author", Params: map[string]string{"sslmode": "disable"}},
+			"postgres://author:p%40ss%2Fword@db:5432/author?sslmode=disable",
+		},
+		{
+			"sqlite",
+			DBConfig{DBType: DBSqlite, DBName: "/tmp/author.db"},
+			"/tmp/author.db",
+		},
+		{
+			"sqlite with params",
+			DBConfig{DBType: DBSqlite, DBName: "/tmp/author.db", Params: map[string]string{"_busy_timeout": "5000"}},
+			"file:/tmp/author.db?_busy_timeout=5000",
+		},
+	}
+	for _, tt := range tests {
+		dsn, err := tt.config.DSN()
+		if err != nil || dsn != tt.want {
+			t.Errorf("%s: DSN = %q, %v, want %q", tt.name, dsn, err, tt.want)
+		}
+	}
+
+	if _, err := (DBConfig{DBType: "oracle"}).DSN(); err == nil || !strings.Contains(err.Error(), "oracle") {
+		t.Errorf("unsupported dbType err = %v", err)
+	}
+}
+
+// mysql 추가 옵션은 기본 옵션을 덮어씀
+func TestDBConfigDSNMysqlParams(t *testing.T) {
+	config := DBConfig{DBType: DBMysql, Host: "db", Port: 3306, User: "author", DBName: "author", Params: map[string]string{"charset": "utf8mb4", "tls": "true"}}
+
+	dsn, err := config.DSN()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dsn, "charset=utf8mb4") || strings.Contains(dsn, "charset=utf8&") || !strings.Contains(dsn, "tls=true") {
+		t.Errorf("DSN = %s", dsn)
+	}
+}
@@ -11,11 +11,13 @@ const KeyToken = "Token:"
 const KeyOperation = "Op:"
 const KeyAuth = "Auth:"               // Auth:{TokenId}:{AppId}, 키-앱 인증 정보
 const KeyAppTrafficPrefix = "AppTf:"  // AppTf:{AppId}:{Unit}, 앱의 단위시간당 트래픽 허용치
-const KeyTrafficPrefix = "Tf:"        // Tf:{TokenId}:{AppId}:{unit}, 키-앱-단위 호출 횟수
-const KeyTrafficDetailPrefix = "TfD:" // TfD:{TokenId}:{AppId}:{OperationId}:{unit}, 키-앱-단위 호출 횟수
+const KeyTrafficPrefix = "Tf:"        // Tf:{TokenId:AppId}:{unit}, 키-앱-단위 호출 횟수
+const KeyTrafficDetailPrefix = "TfD:" // TfD:{TokenId:AppId}:{OperationId}:{unit}, 키-앱-operation-단위 호출 횟수
 const KEY_TRAFFIC_SET = "TrafficSet:"
 const KeyTrafficDetailSet = "TrafficDetailSet:"
 const KEY_TRAFFIC_QUEUE = "TrafficQueue"
+const KeyMfaChallenge = "Mfa:"  // Mfa:{MfaToken}, 2단계 인증 대기 중인 회원 ID
+const KeyMfaAttempt = "MfaTry:" // MfaTry:{MfaToken}, 2단계 인증 실패 횟수
 
 func GetTrafficUnits() []string {
 	return []string{
@@ -26,3 +28,41 @@ func GetTrafficUnits() []string {
 const JwtSecret = "infuser-auther-jwt-secret"
 const JwtExpInterval = 1 * time.Hour
 const RefreshTokenExpInterval = 24 * time.Hour
+const AppTokenCacheExpInterval = 24 * time.Hour
+
+const MfaChallengeExpInterval = 5 * time.Minute
+const MfaMaxAttempts = 5      // 2단계 인증 대기 토큰별 최대 시도 횟수
+const MfaUserMaxFailures = 10 // 회원별 연속 실패 허용 횟수, 초과시 MfaLockoutInterval 동안 잠금
+const MfaLockoutInterval = 15 * time.Minute
+const MfaRecoveryCodeCount = 10
+
+const DefaultShutdownTimeout = 10 * time.Second
+const HealthCheckInterval = 5 * time.Second
+const DefaultQueryTimeout = 5 * time.Second
+const DefaultRedisTimeout = 1 * time.Second
+const DefaultRedisBreakerThreshold = 5
+const DefaultRedisBreakerCooldown = 10 * time.Second
+
+const RoleAdmin = "admin"
+
+// App 상태, draft와 suspended는 API 호출을 거부하고 deprecated는 SunsetAt 이후 거부
+const AppStatusDraft = "draft"
+const AppStatusActive = "active"
+const AppStatusSuspended = "suspended"
+const AppStatusDeprecated = "deprecated"
+
+const DefaultAppRetention = 90 * 24 * time.Hour // 삭제된 App을 복구할 수 있는 기간, 이후 영구 삭제
+const PurgeBatchSize = 100                      // 한 트랜잭션에서 영구 삭제할 App 수
+const PurgeLockName = "author_app_purge"        // 영구 삭제를 한 인스턴스에서만 실행하기 위한 lock (MySQL)
+const PurgeLockId = 6120930018                  // PostgreSQL advisory lock key
+
+const DefaultPageSize = 20
+const MaxPageSize = 100
+
+const EmailVerifyExpInterval = 24 * time.Hour
+const ResetPasswordExpInterval = 1 * time.Hour
+const ResetPasswordResendInterval = 1 * time.Minute // 같은 회원에게 재설정 메일을 다시 보내기까지의 최소 간격
+
+const OAuthCodeExpInterval = 10 * time.Minute
+const OAuthAccessTokenExpInterval = 1 * time.Hour
+const OAuthRefreshTokenExpInterval = 30 * 24 * time.Hour
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrCircuitOpen 차단기가 열린 동안 Redis 명령을 보내지 않고 반환하는 오류
+var ErrCircuitOpen = errors.New("redis: circuit open")
+
+// CircuitBreaker 연속된 Redis 장애 오류가 threshold에 도달하면 cooldown 동안 명령을 차단하는 hook
+// cooldown이 지나면 다음 명령을 시험 삼아 보내고, 성공하면 차단을 해제
+// tracing 등 다른 hook보다 먼저 등록해야 차단된 명령이 기록되지 않음
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	onChange  func(open bool)
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker onChange는 차단/해제 시점에 호출 (로그, 지표 기록용)
+func NewCircuitBreaker(threshold int, cooldown time.Duration, onChange func(open bool)) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+	}
+}
+
+// Available 차단되지 않았거나 cooldown이 지나 시험 명령을 보낼 수 있으면 true
+func (b *CircuitBreaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.open || time.Since(b.openedAt) >= b.cooldown
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return nil
+	}
+	if time.Since(b.openedAt) < b.cooldown {
+		return ErrCircuitOpen
+	}
+
+	// 시험 명령이 끝나기 전 다른 명령이 몰리지 않도록 차단 시각을 갱신
+	b.openedAt = time.Now()
+	return nil
+}
+
+func (b *CircuitBreaker) done(err error) {
+	b.mu.Lock()
+
+	changed := false
+	if isUnavailable(err) {
+		b.failures++
+		if b.open {
+			b.openedAt = time.Now()
+		} else if b.failures >= b.threshold {
+			b.open = true
+			b.openedAt = time.Now()
+			changed = true
+		}
+	} else {
+		b.failures = 0
+		if b.open {
+			b.open = false
+			changed = true
+		}
+	}
+	open := b.open
+
+	b.mu.Unlock()
+
+	if changed && b.onChange != nil {
+		b.onChange(open)
+	}
+}
+
+// Redis 서버가 응답한 오류(redis.Nil 포함)와 클라이언트의 요청 취소는 장애로 보지 않음
+func isUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if _, ok := err.(redis.Error); ok {
+		return false
+	}
+
+	return true
+}
+
+func (b *CircuitBreaker) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return ctx, b.allow()
+}
+
+func (b *CircuitBreaker) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	b.done(cmd.Err())
+	return nil
+}
+
+func (b *CircuitBreaker) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, b.allow()
+}
+
+func (b *CircuitBreaker) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if isUnavailable(cmd.Err()) {
+			err = cmd.Err()
+			break
+		}
+	}
+	b.done(err)
+	return nil
+}
@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type stateRecorder struct {
+	mu      sync.Mutex
+	changes []bool
+}
+
+func (r *stateRecorder) record(open bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes = append(r.changes, open)
+}
+
+func (r *stateRecorder) get() []bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]bool{}, r.changes...)
+}
+
+var errUnavailable = errors.New("dial tcp: connection refused")
+
+func TestCircuitBreakerOpen(t *testing.T) {
+	recorder := &stateRecorder{}
+	b := NewCircuitBreaker(3, time.Hour, recorder.record)
+
+	for i := 0; i < 2; i++ {
+		b.done(errUnavailable)
+	}
+	if !b.Available() || b.allow() != nil {
+		t.Fatal("breaker must stay closed below threshold")
+	}
+
+	// 성공하면 연속 실패 횟수 초기화
+	b.done(nil)
+	b.done(errUnavailable)
+	b.done(errUnavailable)
+	if !b.Available() {
+		t.Fatal("failures must be consecutive")
+	}
+
+	b.done(errUnavailable)
+	if b.Available() || b.allow() != ErrCircuitOpen {
+		t.Fatal("breaker must open at threshold")
+	}
+	if changes := recorder.get(); len(changes) != 1 || !changes[0] {
+		t.Errorf("changes = %v, want [true]", changes)
+	}
+}
+
+// Redis 서버의 오류 응답, 키 없음, 요청 취소는 장애로 보지 않음
+func TestCircuitBreakerIgnoredErrors(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Hour, nil)
+
+	ignored := []error{nil, redis.Nil, context.Canceled}
+	for _, err := range ignored {
+		b.done(err)
+	}
+	if !b.Available() {
+		t.Error("ignored errors must not open the breaker")
+	}
+
+	b.done(context.DeadlineExceeded)
+	if b.Available() {
+		t.Error("timeout must open the breaker")
+	}
+}
+
+// cooldown 후 시험 명령을 한 번 허용하고, 성공하면 해제, 실패하면 다시 cooldown
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	recorder := &stateRecorder{}
+	cooldown := 50 * time.Millisecond
+	b := NewCircuitBreaker(1, cooldown, recorder.record)
+
+	b.done(errUnavailable)
+	if b.allow() != ErrCircuitOpen {
+		t.Fatal("breaker must be open")
+	}
+
+	time.Sleep(cooldown)
+	if !b.Available() || b.allow() != nil {
+		t.Fatal("trial command must be allowed after cooldown")
+	}
+	if b.allow() != ErrCircuitOpen {
+		t.Error("only one trial command must be allowed per cooldown")
+	}
+
+	b.done(errUnavailable)
+	if b.Available() {
+		t.Error("failed trial must restart cooldown")
+	}
+
+	time.Sleep(cooldown)
+	if b.allow() != nil {
+		t.Fatal("trial command must be allowed after cooldown")
+	}
+	b.done(nil)
+	if !b.Available() || b.allow() != nil {
+		t.Error("successful trial must close the breaker")
+	}
+
+	if changes := recorder.get(); len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("changes = %v, want [true false]", changes)
+	}
+}
+
+// 접속할 수 없는 Redis에 hook으로 등록하면 threshold 이후 명령을 보내지 않고 ErrCircuitOpen 반환
+func TestCircuitBreakerHook(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	b := NewCircuitBreaker(2, time.Hour, nil)
+	client.AddHook(b)
+	rdb := NewRedisDB(client, 100*time.Millisecond, b)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := rdb.GetUint(ctx, "key"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: err = %v, want connection error", i, err)
+		}
+	}
+	if rdb.Available() {
+		t.Fatal("RedisDB must be unavailable after threshold")
+	}
+
+	if _, err := rdb.GetUint(ctx, "key"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen", err)
+	}
+
+	_, pipeErr := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, "key")
+		return nil
+	})
+	if !errors.Is(pipeErr, ErrCircuitOpen) {
+		t.Errorf("pipeline err = %v, want ErrCircuitOpen", pipeErr)
+	}
+}
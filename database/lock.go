@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// AdvisoryLock 여러 인스턴스 중 하나만 작업하도록 DB advisory lock 획득
+// timeout이 0이면 기다리지 않고 한 번만 시도, 0보다 크면 timeout까지 대기하며 획득하지 못하면 acquired가 false
+// lock은 연결 단위이므로 unlock 호출 전까지 같은 연결을 유지
+// MySQL은 name, PostgreSQL은 id를 사용하며 SQLite는 단일 인스턴스로 보고 항상 획득
+func AdvisoryLock(ctx context.Context, orm *xorm.Engine, name string, id int64, timeout time.Duration) (unlock func() error, acquired bool, err error) {
+	dbType := orm.Dialect().URI().DBType
+	if dbType != schemas.MYSQL && dbType != schemas.POSTGRES {
+		return func() error { return nil }, true, nil
+	}
+
+	conn, err := orm.DB().Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var unlockSql string
+	var lockArg interface{}
+	switch dbType {
+	case schemas.MYSQL:
+		// GET_LOCK은 대기 시간(초)이 지나면 0 반환
+		var result sql.NullInt64
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(timeout.Seconds())).Scan(&result)
+		acquired = result.Int64 == 1
+		unlockSql, lockArg = "SELECT RELEASE_LOCK(?)", name
+	case schemas.POSTGRES:
+		if timeout <= 0 {
+			err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&acquired)
+		} else {
+			// pg_advisory_lock은 대기 시간 지정이 없으므로 context로 대기를 중단
+			lockCtx, cancel := context.WithTimeout(ctx, timeout)
+			_, err = conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", id)
+			acquired = err == nil
+			if !acquired && ctx.Err() == nil && lockCtx.Err() == context.DeadlineExceeded {
+				err = nil
+			}
+			cancel()
+		}
+		unlockSql, lockArg = "SELECT pg_advisory_unlock($1)", id
+	}
+	if err != nil || !acquired {
+		conn.Close()
+		return nil, false, err
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), unlockSql, lockArg)
+		return err
+	}, true, nil
+}
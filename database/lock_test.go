@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"xorm.io/xorm"
+)
+
+// SQLite는 단일 인스턴스로 보고 lock 없이 항상 획득
+func TestAdvisoryLockSqlite(t *testing.T) {
+	orm, err := xorm.NewEngine("sqlite3", filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer orm.Close()
+
+	// 대기 없는 시도와 대기하는 획득 모두 같은 동작
+	for _, timeout := range []time.Duration{0, time.Second} {
+		unlock, acquired, err := AdvisoryLock(context.Background(), orm, "test", 1, timeout)
+		if err != nil || !acquired {
+			t.Fatalf("AdvisoryLock(%v) = %v, %v", timeout, acquired, err)
+		}
+		if err := unlock(); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CacheOutbox 트랜잭션 중 삭제할 캐시 키를 모아 두었다가 커밋 후 한 번에 삭제
+// 롤백되면 Flush하지 않으므로 커밋되지 않은 변경으로 캐시가 지워지거나 다시 채워지지 않음
+type CacheOutbox struct {
+	keys []string
+}
+
+func (o *CacheOutbox) Add(keys ...string) {
+	o.keys = append(o.keys, keys...)
+}
+
+// Flush 모아 둔 키 삭제, cluster 모드에서도 slot이 다른 키를 함께 지울 수 있도록 키별 DEL 사용
+func (o *CacheOutbox) Flush(ctx context.Context, rdb *RedisDB) error {
+	if len(o.keys) == 0 {
+		return nil
+	}
+
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range o.keys {
+			pipe.Del(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	o.keys = nil
+
+	return nil
+}
@@ -2,78 +2,256 @@ package database
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
-	log "github.com/sirupsen/logrus"
 )
 
 type RedisDB struct {
-	client  *redis.Client
-	context context.Context
+	client  redis.UniversalClient
+	timeout time.Duration
+	breaker *CircuitBreaker
 }
 
-func NewRedisDB(context context.Context, client *redis.Client) *RedisDB {
+// NewRedisDB timeout은 명령별 제한 시간, 0이면 요청 context의 deadline만 적용
+// breaker는 client에 hook으로 등록된 차단기, nil이면 항상 사용 가능한 것으로 판단
+func NewRedisDB(client redis.UniversalClient, timeout time.Duration, breaker *CircuitBreaker) *RedisDB {
 	rdb := new(RedisDB)
 	rdb.client = client
-	rdb.context = context
+	rdb.timeout = timeout
+	rdb.breaker = breaker
 
 	return rdb
 }
 
-func (r *RedisDB) Set(key string, value interface{}) (string, error) {
-	_, err := r.client.Set(r.context, key, value, 0).Result()
+// Available 차단기가 열려 Redis 명령을 보낼 수 없으면 false
+func (r *RedisDB) Available() bool {
+	return r.breaker == nil || r.breaker.Available()
+}
+
+// ErrInvalidValue 저장된 값을 요청한 형식으로 변환할 수 없음, 캐시 미적중과 같이 원본에서 다시 조회
+var ErrInvalidValue = errors.New("redis: invalid value")
+
+// IsMiss 키가 없거나 저장된 값을 사용할 수 없어 원본에서 다시 조회해야 하는 오류
+func IsMiss(err error) bool {
+	return err == redis.Nil || errors.Is(err, ErrInvalidValue)
+}
+
+func parseUint(key string, value string) (uint, error) {
+	result, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrInvalidValue, key, err)
+	}
+
+	return uint(result), nil
+}
+
+// 요청 context에 명령별 제한 시간 적용
+func (r *RedisDB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *RedisDB) Set(ctx context.Context, key string, value interface{}) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.client.Set(ctx, key, value, 0).Result()
 	return key, err
 }
 
-func (r *RedisDB) SetWithExpiration(key string, value interface{}, expiration time.Duration) (string, error) {
-	_, err := r.client.Set(r.context, key, value, expiration).Result()
+func (r *RedisDB) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.client.Set(ctx, key, value, expiration).Result()
 	return key, err
 }
 
-func (r *RedisDB) Get(key string, resultType string) (interface{}, error) {
-	result, err := r.client.Get(r.context, key).Result()
+func (r *RedisDB) GetString(ctx context.Context, key string) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Get(ctx, key).Result()
+}
+
+func (r *RedisDB) GetUint(ctx context.Context, key string) (uint, error) {
+	result, err := r.GetString(ctx, key)
 	if err != nil {
+		return 0, err
+	}
+
+	return parseUint(key, result)
+}
+
+// GetJSON JSON으로 저장된 값을 v에 복원
+func (r *RedisDB) GetJSON(ctx context.Context, key string, v interface{}) error {
+	result, err := r.GetString(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(result), v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidValue, key, err)
+	}
+
+	return nil
+}
+
+// SetJSON v를 JSON으로 저장, expiration이 0이면 만료 시간 없음
+func (r *RedisDB) SetJSON(ctx context.Context, key string, v interface{}, expiration time.Duration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.SetWithExpiration(ctx, key, b, expiration)
+	return err
+}
+
+// GetUints 여러 키를 한 번에 조회, 값이 있는 키만 결과에 포함
+// cluster 모드에서 키의 슬롯이 달라도 동작하도록 MGET 대신 pipeline 사용
+func (r *RedisDB) GetUints(ctx context.Context, keys ...string) (map[string]uint, error) {
+	cmds, err := r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
 		return nil, err
 	}
 
-	switch resultType {
-	case "uint":
-		temp, err := strconv.ParseUint(result, 10, 32)
+	values := map[string]uint{}
+	for i, cmd := range cmds {
+		result, err := cmd.(*redis.StringCmd).Result()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		value, err := parseUint(keys[i], result)
+		if err != nil {
+			return nil, err
+		}
+		values[keys[i]] = value
+	}
+
+	return values, nil
+}
+
+// Pipelined fn에서 추가한 명령을 한 번에 전송, 첫 번째 오류를 함께 반환
+func (r *RedisDB) Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Pipelined(ctx, fn)
+}
+
+// RunScript EVALSHA로 실행하고 스크립트가 캐시되지 않은 경우 EVAL로 재실행
+// cluster 모드에서는 keys가 모두 같은 슬롯(hash tag)이어야 함
+func (r *RedisDB) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return script.Run(ctx, r.client, keys, args...).Result()
+}
+
+// ScanKeys match 패턴의 키 목록, KEYS 대신 SCAN으로 나누어 조회
+// cluster 모드에서는 연결된 노드 하나만 조회하므로 standalone, sentinel 모드에서만 사용
+func (r *RedisDB) ScanKeys(ctx context.Context, match string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		scanCtx, cancel := r.withTimeout(ctx)
+		result, next, err := r.client.Scan(scanCtx, cursor, match, 1000).Result()
+		cancel()
 		if err != nil {
-			log.WithFields(log.Fields{
-				"module": "redis",
-			}).Fatal(err)
-			return temp, err
+			return nil, err
+		}
+
+		keys = append(keys, result...)
+		if next == 0 {
+			return keys, nil
 		}
-		return uint(temp), nil
-	default:
-		return result, err
+		cursor = next
 	}
 }
 
-func (r *RedisDB) Delete(key string) (string, error) {
-	_, err := r.client.Del(r.context, key).Result()
+func (r *RedisDB) Delete(ctx context.Context, key string) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.client.Del(ctx, key).Result()
 	return key, err
 }
 
-func (r *RedisDB) Incr(key string) (int64, error) {
-	return r.client.Incr(r.context, key).Result()
+func (r *RedisDB) Expire(ctx context.Context, key string, expiration time.Duration) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Expire(ctx, key, expiration).Result()
 }
 
-func (r *RedisDB) SAdd(key string, member string) (int64, error) {
-	return r.client.SAdd(r.context, key, member).Result()
+func (r *RedisDB) Incr(ctx context.Context, key string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Incr(ctx, key).Result()
 }
 
-func (r *RedisDB) SMembers(key string) ([]string, error) {
-	return r.client.SMembers(r.context, key).Result()
+func (r *RedisDB) SAdd(ctx context.Context, key string, member string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.SAdd(ctx, key, member).Result()
 }
 
-func (r *RedisDB) LPush(key string, value string) (int64, error) {
-	return r.client.LPush(r.context, key, value).Result()
+func (r *RedisDB) SMembers(ctx context.Context, key string) ([]string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.SMembers(ctx, key).Result()
 }
 
-func (r *RedisDB) LPop(key string) (string, error) {
-	return r.client.LPop(r.context, key).Result()
+func (r *RedisDB) LPush(ctx context.Context, key string, value string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.LPush(ctx, key, value).Result()
+}
+
+func (r *RedisDB) LPop(ctx context.Context, key string) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.LPop(ctx, key).Result()
+}
+
+func (r *RedisDB) Ping(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Ping(ctx).Err()
+}
+
+func (r *RedisDB) Close() error {
+	return r.client.Close()
+}
+
+// PoolStats 연결 풀 상태, cluster 모드는 전체 노드의 합계
+func (r *RedisDB) PoolStats() *redis.PoolStats {
+	if client, ok := r.client.(interface{ PoolStats() *redis.PoolStats }); ok {
+		return client.PoolStats()
+	}
+
+	return &redis.PoolStats{}
 }
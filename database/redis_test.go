@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 연결은 받지만 응답하지 않는 Redis에서 호출별 제한 시간과 요청 context 취소가 적용되어야 함
+func TestRedisDBTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: l.Addr().String(), MaxRetries: -1, ReadTimeout: time.Minute})
+	defer client.Close()
+	rdb := NewRedisDB(client, 100*time.Millisecond, nil)
+
+	start := time.Now()
+	if _, err := rdb.GetString(context.Background(), "key"); err == nil {
+		t.Error("get succeeded without response")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("get returned after %v, want call timeout", elapsed)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := rdb.Ping(canceled); err == nil {
+		t.Error("ping succeeded with canceled context")
+	}
+}
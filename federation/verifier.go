@@ -0,0 +1,263 @@
+// Package federation 외부 OIDC IdP에서 발급한 ID 토큰 검증
+package federation
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/oidc"
+)
+
+const (
+	keySetTTL        = time.Hour
+	keySetMinRefresh = time.Minute // 알 수 없는 kid로 인한 JWKS 재조회 최소 간격
+	httpTimeout      = 10 * time.Second
+)
+
+// IssuerConfig : 신뢰하는 외부 IdP 설정 (config.yaml의 federation.issuers 항목)
+type IssuerConfig struct {
+	Issuer        string `yaml:"issuer"`        // ID 토큰의 iss 값과 정확히 일치해야 함
+	JwksUri       string `yaml:"jwksUri"`       // 비어 있으면 {issuer}/.well-known/openid-configuration 에서 조회
+	ClientId      string `yaml:"clientId"`      // ID 토큰의 aud에 포함되어야 하는 값
+	AutoProvision bool   `yaml:"autoProvision"` // 연결된 회원이 없으면 회원 자동 생성
+	LinkByEmail   bool   `yaml:"linkByEmail"`   // IdP에서 확인된 이메일이 같은 기존 회원에 연결
+}
+
+// Config : 외부 IdP 연동 설정 (config.yaml의 federation 항목)
+type Config struct {
+	Issuers []IssuerConfig `yaml:"issuers"`
+}
+
+// Identity : 검증된 ID 토큰의 회원 정보
+type Identity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Config            IssuerConfig
+}
+
+type keySet struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type Verifier struct {
+	issuers map[string]IssuerConfig
+	client  *http.Client
+	mutex   sync.Mutex
+	keySets map[string]*keySet
+}
+
+// NewVerifier client가 nil이면 기본 HTTP 클라이언트 사용
+func NewVerifier(config Config, client *http.Client) *Verifier {
+	if client == nil {
+		client = &http.Client{Timeout: httpTimeout}
+	}
+
+	v := &Verifier{
+		issuers: make(map[string]IssuerConfig),
+		client:  client,
+		keySets: make(map[string]*keySet),
+	}
+	for _, issuer := range config.Issuers {
+		v.issuers[issuer.Issuer] = issuer
+	}
+
+	return v
+}
+
+// Verify 설정된 IdP의 JWKS로 RS256 서명과 iss, aud, exp를 검증
+// 서명 검증 실패는 401, 신뢰하지 않는 발급자는 403, JWKS 조회 실패는 기본 코드(503)로 반환
+func (v *Verifier) Verify(rawIdToken string) (*Identity, error) {
+	unverified := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(rawIdToken, unverified); err != nil {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid id token")
+	}
+
+	iss, _ := unverified["iss"].(string)
+	config, ok := v.issuers[iss]
+	if !ok {
+		return nil, errors.NewWithCode(http.StatusForbidden, "untrusted issuer: "+iss)
+	}
+
+	var fetchErr error
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: []string{oidc.SigningAlg}}
+	_, err := parser.ParseWithClaims(rawIdToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.publicKey(config, kid)
+		if code, _ := errors.Decompose(err); err != nil && code != http.StatusUnauthorized {
+			fetchErr = err
+		}
+		return key, err
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err != nil {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid id token; "+err.Error())
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid id token; missing exp")
+	}
+	if !hasAudience(claims["aud"], config.ClientId) {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid id token; audience mismatch")
+	}
+
+	identity := &Identity{Issuer: iss, Config: config}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	// 일부 IdP는 email_verified를 문자열로 전달
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	if len(identity.Subject) == 0 {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid id token; missing sub")
+	}
+
+	return identity, nil
+}
+
+// 캐시된 JWKS에서 kid에 해당하는 공개키 조회
+// 만료되었거나 알 수 없는 kid인 경우(IdP 키 교체) JWKS를 다시 조회
+func (v *Verifier) publicKey(config IssuerConfig, kid string) (*rsa.PublicKey, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if set, ok := v.keySets[config.Issuer]; ok {
+		age := time.Since(set.fetchedAt)
+		if key := set.find(kid); key != nil && age < keySetTTL {
+			return key, nil
+		}
+		if age < keySetMinRefresh {
+			return nil, errors.NewWithCode(http.StatusUnauthorized, "unknown key id: "+kid)
+		}
+	}
+
+	set, err := v.fetchKeySet(config)
+	if err != nil {
+		return nil, err
+	}
+	v.keySets[config.Issuer] = set
+
+	key := set.find(kid)
+	if key == nil {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "unknown key id: "+kid)
+	}
+
+	return key, nil
+}
+
+func (v *Verifier) fetchKeySet(config IssuerConfig) (*keySet, error) {
+	jwksUri := config.JwksUri
+	if len(jwksUri) == 0 {
+		discovery := struct {
+			JwksUri string `json:"jwks_uri"`
+		}{}
+		if err := v.getJSON(strings.TrimSuffix(config.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, err
+		}
+		jwksUri = discovery.JwksUri
+	}
+
+	jwks := oidc.JSONWebKeySet{}
+	if err := v.getJSON(jwksUri, &jwks); err != nil {
+		return nil, err
+	}
+
+	set := &keySet{keys: make(map[string]*rsa.PublicKey), fetchedAt: time.Now()}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (len(jwk.Use) > 0 && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := rsaPublicKey(jwk)
+		if err != nil {
+			return nil, errors.NewWithPrefix(err, "invalid jwks: "+jwksUri)
+		}
+		set.keys[jwk.Kid] = key
+	}
+
+	return set, nil
+}
+
+func (v *Verifier) getJSON(url string, result interface{}) error {
+	res, err := v.client.Get(url)
+	if err != nil {
+		return errors.NewWithPrefix(err, "federation request failed")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New(fmt.Sprintf("federation request failed; %s returned %d", url, res.StatusCode))
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return errors.NewWithPrefix(err, "federation response decode failed: "+url)
+	}
+
+	return nil
+}
+
+// kid가 없는 토큰은 키가 하나뿐인 경우에만 허용
+func (s *keySet) find(kid string) *rsa.PublicKey {
+	if len(kid) == 0 && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+
+	return s.keys[kid]
+}
+
+func rsaPublicKey(jwk oidc.JSONWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// aud는 문자열 또는 문자열 배열
+func hasAudience(aud interface{}, clientId string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientId
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == clientId {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,215 @@
+package federation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/oidc"
+)
+
+const testClientId = "author"
+
+// testIdP discovery 문서와 JWKS를 제공하는 외부 IdP
+type testIdP struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	keys     []*oidc.Provider
+	requests int
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": idp.server.URL, "jwks_uri": idp.server.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.mutex.Lock()
+		defer idp.mutex.Unlock()
+
+		idp.requests++
+		jwks := oidc.JSONWebKeySet{}
+		for _, key := range idp.keys {
+			jwks.Keys = append(jwks.Keys, key.JWKS().Keys...)
+		}
+		json.NewEncoder(w).Encode(jwks)
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	idp.rotate(t)
+
+	return idp
+}
+
+// rotate 새 서명 키로 교체, JWKS에는 새 키만 게시
+func (idp *testIdP) rotate(t *testing.T) *oidc.Provider {
+	t.Helper()
+
+	key, err := oidc.NewProvider(oidc.Config{Issuer: idp.server.URL, AllowEphemeralKey: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+	idp.keys = []*oidc.Provider{key}
+
+	return key
+}
+
+func (idp *testIdP) signer() *oidc.Provider {
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+
+	return idp.keys[0]
+}
+
+func (idp *testIdP) jwksRequests() int {
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+
+	return idp.requests
+}
+
+func (idp *testIdP) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"aud":            testClientId,
+		"sub":            "subject-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "alice@example.com",
+		"email_verified": "true",
+	}
+}
+
+func sign(t *testing.T, key *oidc.Provider, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := key.Sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func newTestVerifier(idp *testIdP) *Verifier {
+	return NewVerifier(Config{Issuers: []IssuerConfig{{Issuer: idp.server.URL, ClientId: testClientId}}}, idp.server.Client())
+}
+
+func statusOf(err error) int {
+	status, _ := errors.Decompose(err)
+	return status
+}
+
+func TestVerify(t *testing.T) {
+	idp := newTestIdP(t)
+	v := newTestVerifier(idp)
+
+	identity, err := v.Verify(sign(t, idp.signer(), idp.claims()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Subject != "subject-1" || identity.Email != "alice@example.com" || !identity.EmailVerified {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// 배열 형식의 aud
+	claims := idp.claims()
+	claims["aud"] = []string{"other", testClientId}
+	if _, err := v.Verify(sign(t, idp.signer(), claims)); err != nil {
+		t.Errorf("aud array: %v", err)
+	}
+}
+
+func TestVerifyInvalidClaims(t *testing.T) {
+	idp := newTestIdP(t)
+	v := newTestVerifier(idp)
+
+	tests := map[string]struct {
+		modify func(claims jwt.MapClaims)
+		status int
+	}{
+		"untrusted iss": {func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }, http.StatusForbidden},
+		"wrong aud":     {func(claims jwt.MapClaims) { claims["aud"] = "other" }, http.StatusUnauthorized},
+		"no aud":        {func(claims jwt.MapClaims) { delete(claims, "aud") }, http.StatusUnauthorized},
+		"expired":       {func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }, http.StatusUnauthorized},
+		"no exp":        {func(claims jwt.MapClaims) { delete(claims, "exp") }, http.StatusUnauthorized},
+		"no sub":        {func(claims jwt.MapClaims) { delete(claims, "sub") }, http.StatusUnauthorized},
+	}
+	for name, tt := range tests {
+		claims := idp.claims()
+		tt.modify(claims)
+
+		_, err := v.Verify(sign(t, idp.signer(), claims))
+		if status := statusOf(err); err == nil || status != tt.status {
+			t.Errorf("%s: err = %v (%d), want %d", name, err, status, tt.status)
+		}
+	}
+}
+
+// 게시되지 않은 키로 서명한 토큰은 거부
+func TestVerifyUnknownKey(t *testing.T) {
+	idp := newTestIdP(t)
+	v := newTestVerifier(idp)
+
+	other, err := oidc.NewProvider(oidc.Config{Issuer: idp.server.URL, AllowEphemeralKey: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = v.Verify(sign(t, other, idp.claims()))
+	if statusOf(err) != http.StatusUnauthorized {
+		t.Errorf("err = %v, want 401", err)
+	}
+}
+
+// IdP가 키를 교체하면 알 수 없는 kid로 JWKS를 다시 조회, 재조회는 keySetMinRefresh 간격으로 제한
+func TestVerifyKeyRotation(t *testing.T) {
+	idp := newTestIdP(t)
+	v := newTestVerifier(idp)
+
+	if _, err := v.Verify(sign(t, idp.signer(), idp.claims())); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := sign(t, idp.rotate(t), idp.claims())
+	if _, err := v.Verify(rotated); statusOf(err) != http.StatusUnauthorized {
+		t.Errorf("err = %v, want 401 within keySetMinRefresh", err)
+	}
+	if requests := idp.jwksRequests(); requests != 1 {
+		t.Errorf("jwks requests = %d, want 1", requests)
+	}
+
+	v.mutex.Lock()
+	v.keySets[idp.server.URL].fetchedAt = time.Now().Add(-keySetMinRefresh)
+	v.mutex.Unlock()
+
+	if _, err := v.Verify(rotated); err != nil {
+		t.Errorf("rotated key: %v", err)
+	}
+	if requests := idp.jwksRequests(); requests != 2 {
+		t.Errorf("jwks requests = %d, want 2", requests)
+	}
+}
+
+func TestVerifyJwksUnavailable(t *testing.T) {
+	idp := newTestIdP(t)
+	v := newTestVerifier(idp)
+	token := sign(t, idp.signer(), idp.claims())
+	idp.server.Close()
+
+	_, err := v.Verify(token)
+	if err == nil || statusOf(err) == http.StatusUnauthorized {
+		t.Errorf("err = %v, want fetch error", err)
+	}
+}
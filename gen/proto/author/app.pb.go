@@ -0,0 +1,1572 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.3
+// source: proto/author/app.proto
+
+package grpc_author
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// DRAFT: 공개 전, SUSPENDED: 일시 정지, DEPRECATED: 사용 중단 예정 (sunset_at 이후 호출 거부)
+type AppStatus int32
+
+const (
+	AppStatus_ACTIVE     AppStatus = 0
+	AppStatus_DRAFT      AppStatus = 1
+	AppStatus_SUSPENDED  AppStatus = 2
+	AppStatus_DEPRECATED AppStatus = 3
+)
+
+// Enum value maps for AppStatus.
+var (
+	AppStatus_name = map[int32]string{
+		0: "ACTIVE",
+		1: "DRAFT",
+		2: "SUSPENDED",
+		3: "DEPRECATED",
+	}
+	AppStatus_value = map[string]int32{
+		"ACTIVE":     0,
+		"DRAFT":      1,
+		"SUSPENDED":  2,
+		"DEPRECATED": 3,
+	}
+)
+
+func (x AppStatus) Enum() *AppStatus {
+	p := new(AppStatus)
+	*p = x
+	return p
+}
+
+func (x AppStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_author_app_proto_enumTypes[0].Descriptor()
+}
+
+func (AppStatus) Type() protoreflect.EnumType {
+	return &file_proto_author_app_proto_enumTypes[0]
+}
+
+func (x AppStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppStatus.Descriptor instead.
+func (AppStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{0}
+}
+
+// Redis 장애 시 트래픽 제한 방식
+// FAIL_CLOSED: 인스턴스 메모리로 제한, FAIL_OPEN: 인증만 확인하고 제한하지 않음
+type AppReq_FailPolicy int32
+
+const (
+	AppReq_FAIL_CLOSED AppReq_FailPolicy = 0
+	AppReq_FAIL_OPEN   AppReq_FailPolicy = 1
+)
+
+// Enum value maps for AppReq_FailPolicy.
+var (
+	AppReq_FailPolicy_name = map[int32]string{
+		0: "FAIL_CLOSED",
+		1: "FAIL_OPEN",
+	}
+	AppReq_FailPolicy_value = map[string]int32{
+		"FAIL_CLOSED": 0,
+		"FAIL_OPEN":   1,
+	}
+)
+
+func (x AppReq_FailPolicy) Enum() *AppReq_FailPolicy {
+	p := new(AppReq_FailPolicy)
+	*p = x
+	return p
+}
+
+func (x AppReq_FailPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppReq_FailPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_author_app_proto_enumTypes[1].Descriptor()
+}
+
+func (AppReq_FailPolicy) Type() protoreflect.EnumType {
+	return &file_proto_author_app_proto_enumTypes[1]
+}
+
+func (x AppReq_FailPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppReq_FailPolicy.Descriptor instead.
+func (AppReq_FailPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type AppRes_Status int32
+
+const (
+	AppRes_OK               AppRes_Status = 0
+	AppRes_ERROR            AppRes_Status = 1
+	AppRes_CONFLICT         AppRes_Status = 2
+	AppRes_NOT_FOUND        AppRes_Status = 3
+	AppRes_INVALID_STATUS   AppRes_Status = 4 // 허용되지 않는 상태 또는 상태 변경
+	AppRes_INVALID_ARGUMENT AppRes_Status = 5 // version 누락 등 잘못된 요청
+)
+
+// Enum value maps for AppRes_Status.
+var (
+	AppRes_Status_name = map[int32]string{
+		0: "OK",
+		1: "ERROR",
+		2: "CONFLICT",
+		3: "NOT_FOUND",
+		4: "INVALID_STATUS",
+		5: "INVALID_ARGUMENT",
+	}
+	AppRes_Status_value = map[string]int32{
+		"OK":               0,
+		"ERROR":            1,
+		"CONFLICT":         2,
+		"NOT_FOUND":        3,
+		"INVALID_STATUS":   4,
+		"INVALID_ARGUMENT": 5,
+	}
+)
+
+func (x AppRes_Status) Enum() *AppRes_Status {
+	p := new(AppRes_Status)
+	*p = x
+	return p
+}
+
+func (x AppRes_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppRes_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_author_app_proto_enumTypes[2].Descriptor()
+}
+
+func (AppRes_Status) Type() protoreflect.EnumType {
+	return &file_proto_author_app_proto_enumTypes[2]
+}
+
+func (x AppRes_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppRes_Status.Descriptor instead.
+func (AppRes_Status) EnumDescriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type AppReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      uint32               `protobuf:"varint,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	NameSpace  string               `protobuf:"bytes,1,opt,name=name_space,json=nameSpace,proto3" json:"name_space,omitempty"`
+	Traffics   []*AppReq_AppTraffic `protobuf:"bytes,3,rep,name=traffics,proto3" json:"traffics,omitempty"`
+	Operations []*AppReq_Operation  `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`
+	FailPolicy AppReq_FailPolicy    `protobuf:"varint,5,opt,name=fail_policy,json=failPolicy,proto3,enum=grpc_author.AppReq_FailPolicy" json:"fail_policy,omitempty"`
+	// Update 시 조회했던 App의 version, 저장된 version과 다르면 CONFLICT
+	// Update에서는 필수이며 0이면 INVALID_ARGUMENT
+	Version uint32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Create 시 ACTIVE 또는 DRAFT, 등록 후에는 ChangeStatus로 변경
+	Status AppStatus `protobuf:"varint,7,opt,name=status,proto3,enum=grpc_author.AppStatus" json:"status,omitempty"`
+}
+
+func (x *AppReq) Reset() {
+	*x = AppReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppReq) ProtoMessage() {}
+
+func (x *AppReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppReq.ProtoReflect.Descriptor instead.
+func (*AppReq) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AppReq) GetAppId() uint32 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *AppReq) GetNameSpace() string {
+	if x != nil {
+		return x.NameSpace
+	}
+	return ""
+}
+
+func (x *AppReq) GetTraffics() []*AppReq_AppTraffic {
+	if x != nil {
+		return x.Traffics
+	}
+	return nil
+}
+
+func (x *AppReq) GetOperations() []*AppReq_Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *AppReq) GetFailPolicy() AppReq_FailPolicy {
+	if x != nil {
+		return x.FailPolicy
+	}
+	return AppReq_FAIL_CLOSED
+}
+
+func (x *AppReq) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AppReq) GetStatus() AppStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AppStatus_ACTIVE
+}
+
+// 변경 가능한 상태
+// DRAFT -> ACTIVE, ACTIVE -> SUSPENDED/DEPRECATED, SUSPENDED -> ACTIVE/DEPRECATED, DEPRECATED -> ACTIVE/SUSPENDED/DEPRECATED
+type ChangeAppStatusReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId    uint32               `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Status   AppStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=grpc_author.AppStatus" json:"status,omitempty"`
+	SunsetAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"` // DEPRECATED일 때 사용 종료 예정 시각, 없으면 종료하지 않음
+	Version  uint32               `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                  // 필수, 0이면 INVALID_ARGUMENT
+}
+
+func (x *ChangeAppStatusReq) Reset() {
+	*x = ChangeAppStatusReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeAppStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAppStatusReq) ProtoMessage() {}
+
+func (x *ChangeAppStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAppStatusReq.ProtoReflect.Descriptor instead.
+func (*ChangeAppStatusReq) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChangeAppStatusReq) GetAppId() uint32 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ChangeAppStatusReq) GetStatus() AppStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AppStatus_ACTIVE
+}
+
+func (x *ChangeAppStatusReq) GetSunsetAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+func (x *ChangeAppStatusReq) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type AppRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status AppRes_Status `protobuf:"varint,1,opt,name=status,proto3,enum=grpc_author.AppRes_Status" json:"status,omitempty"`
+	// 처리 후 App의 version, CONFLICT이면 현재 저장된 version
+	Version uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	App     *AppInfo `protobuf:"bytes,3,opt,name=app,proto3" json:"app,omitempty"`
+}
+
+func (x *AppRes) Reset() {
+	*x = AppRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppRes) ProtoMessage() {}
+
+func (x *AppRes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppRes.ProtoReflect.Descriptor instead.
+func (*AppRes) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AppRes) GetStatus() AppRes_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AppRes_OK
+}
+
+func (x *AppRes) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AppRes) GetApp() *AppInfo {
+	if x != nil {
+		return x.App
+	}
+	return nil
+}
+
+type GetAppReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId          uint32 `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	IncludeDeleted bool   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+}
+
+func (x *GetAppReq) Reset() {
+	*x = GetAppReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAppReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppReq) ProtoMessage() {}
+
+func (x *GetAppReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppReq.ProtoReflect.Descriptor instead.
+func (*GetAppReq) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAppReq) GetAppId() uint32 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *GetAppReq) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// name_space는 앞부분 일치 검색
+type ListAppsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page           uint32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage        uint32 `protobuf:"varint,2,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	NameSpace      string `protobuf:"bytes,3,opt,name=name_space,json=nameSpace,proto3" json:"name_space,omitempty"`
+	IncludeDeleted bool   `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+}
+
+func (x *ListAppsReq) Reset() {
+	*x = ListAppsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAppsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppsReq) ProtoMessage() {}
+
+func (x *ListAppsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppsReq.ProtoReflect.Descriptor instead.
+func (*ListAppsReq) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAppsReq) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAppsReq) GetPerPage() uint32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *ListAppsReq) GetNameSpace() string {
+	if x != nil {
+		return x.NameSpace
+	}
+	return ""
+}
+
+func (x *ListAppsReq) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListAppsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  AppRes_Status `protobuf:"varint,1,opt,name=status,proto3,enum=grpc_author.AppRes_Status" json:"status,omitempty"`
+	Apps    []*AppInfo    `protobuf:"bytes,2,rep,name=apps,proto3" json:"apps,omitempty"`
+	Total   uint32        `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Page    uint32        `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage uint32        `protobuf:"varint,5,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+}
+
+func (x *ListAppsRes) Reset() {
+	*x = ListAppsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAppsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppsRes) ProtoMessage() {}
+
+func (x *ListAppsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppsRes.ProtoReflect.Descriptor instead.
+func (*ListAppsRes) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAppsRes) GetStatus() AppRes_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AppRes_OK
+}
+
+func (x *ListAppsRes) GetApps() []*AppInfo {
+	if x != nil {
+		return x.Apps
+	}
+	return nil
+}
+
+func (x *ListAppsRes) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAppsRes) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAppsRes) GetPerPage() uint32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type AppInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      uint32               `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	NameSpace  string               `protobuf:"bytes,2,opt,name=name_space,json=nameSpace,proto3" json:"name_space,omitempty"`
+	FailPolicy AppReq_FailPolicy    `protobuf:"varint,3,opt,name=fail_policy,json=failPolicy,proto3,enum=grpc_author.AppReq_FailPolicy" json:"fail_policy,omitempty"`
+	Version    uint32               `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt  *timestamp.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Get 응답에만 포함
+	Traffics   []*AppReq_AppTraffic `protobuf:"bytes,8,rep,name=traffics,proto3" json:"traffics,omitempty"`
+	Operations []*AppReq_Operation  `protobuf:"bytes,9,rep,name=operations,proto3" json:"operations,omitempty"`
+	// 사용 현황
+	OperationCount uint32               `protobuf:"varint,10,opt,name=operation_count,json=operationCount,proto3" json:"operation_count,omitempty"` // 삭제되지 않은 operation 수
+	TokenCount     uint32               `protobuf:"varint,11,opt,name=token_count,json=tokenCount,proto3" json:"token_count,omitempty"`             // 사용이 허가된 API 키 중 폐기되지 않은 키 수
+	Status         AppStatus            `protobuf:"varint,12,opt,name=status,proto3,enum=grpc_author.AppStatus" json:"status,omitempty"`
+	SunsetAt       *timestamp.Timestamp `protobuf:"bytes,13,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+}
+
+func (x *AppInfo) Reset() {
+	*x = AppInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppInfo) ProtoMessage() {}
+
+func (x *AppInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppInfo.ProtoReflect.Descriptor instead.
+func (*AppInfo) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AppInfo) GetAppId() uint32 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *AppInfo) GetNameSpace() string {
+	if x != nil {
+		return x.NameSpace
+	}
+	return ""
+}
+
+func (x *AppInfo) GetFailPolicy() AppReq_FailPolicy {
+	if x != nil {
+		return x.FailPolicy
+	}
+	return AppReq_FAIL_CLOSED
+}
+
+func (x *AppInfo) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AppInfo) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AppInfo) GetUpdatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AppInfo) GetDeletedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *AppInfo) GetTraffics() []*AppReq_AppTraffic {
+	if x != nil {
+		return x.Traffics
+	}
+	return nil
+}
+
+func (x *AppInfo) GetOperations() []*AppReq_Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *AppInfo) GetOperationCount() uint32 {
+	if x != nil {
+		return x.OperationCount
+	}
+	return 0
+}
+
+func (x *AppInfo) GetTokenCount() uint32 {
+	if x != nil {
+		return x.TokenCount
+	}
+	return 0
+}
+
+func (x *AppInfo) GetStatus() AppStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AppStatus_ACTIVE
+}
+
+func (x *AppInfo) GetSunsetAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+type AppReq_AppTraffic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Unit  string `protobuf:"bytes,1,opt,name=unit,proto3" json:"unit,omitempty"`
+	Value uint32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Seq   uint32 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *AppReq_AppTraffic) Reset() {
+	*x = AppReq_AppTraffic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppReq_AppTraffic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppReq_AppTraffic) ProtoMessage() {}
+
+func (x *AppReq_AppTraffic) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppReq_AppTraffic.ProtoReflect.Descriptor instead.
+func (*AppReq_AppTraffic) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *AppReq_AppTraffic) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *AppReq_AppTraffic) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AppReq_AppTraffic) GetSeq() uint32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type AppReq_Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EndPoint    string `protobuf:"bytes,1,opt,name=end_point,json=endPoint,proto3" json:"end_point,omitempty"`
+	OperationId uint32 `protobuf:"varint,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Update 시 조회했던 Operation의 version, 기존 Operation을 수정할 때 필수
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *AppReq_Operation) Reset() {
+	*x = AppReq_Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_app_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppReq_Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppReq_Operation) ProtoMessage() {}
+
+func (x *AppReq_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_app_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppReq_Operation.ProtoReflect.Descriptor instead.
+func (*AppReq_Operation) Descriptor() ([]byte, []int) {
+	return file_proto_author_app_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *AppReq_Operation) GetEndPoint() string {
+	if x != nil {
+		return x.EndPoint
+	}
+	return ""
+}
+
+func (x *AppReq_Operation) GetOperationId() uint32 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *AppReq_Operation) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_proto_author_app_proto protoreflect.FileDescriptor
+
+var file_proto_author_app_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2f, 0x61,
+	0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3, 0x04, 0x0a, 0x06, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x71, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65,
+	0x5f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x41,
+	0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x08, 0x74, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x46, 0x61, 0x69,
+	0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x48, 0x0a,
+	0x0a, 0x41, 0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x6e, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x73, 0x65, 0x71, 0x1a, 0x65, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2c,
+	0x0a, 0x0a, 0x46, 0x61, 0x69, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0f, 0x0a, 0x0b,
+	0x46, 0x41, 0x49, 0x4c, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x01, 0x22, 0xae, 0x01, 0x0a,
+	0x12, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x75,
+	0x6e, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x73, 0x75, 0x6e, 0x73, 0x65,
+	0x74, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xe2, 0x01,
+	0x0a, 0x06, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x61, 0x70, 0x70, 0x22, 0x62,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43,
+	0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54,
+	0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10,
+	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x41, 0x52, 0x47, 0x55, 0x4d, 0x45, 0x4e, 0x54,
+	0x10, 0x05, 0x22, 0x4b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x12,
+	0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22,
+	0x84, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0xb0, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x61, 0x70,
+	0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
+	0x61, 0x70, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x70, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x22, 0xf9, 0x04, 0x0a, 0x07, 0x41, 0x70,
+	0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x66,
+	0x61, 0x69, 0x6c, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41,
+	0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x41,
+	0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x08, 0x74, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x09,
+	0x73, 0x75, 0x6e, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x73, 0x75, 0x6e,
+	0x73, 0x65, 0x74, 0x41, 0x74, 0x2a, 0x41, 0x0a, 0x09, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x44, 0x52, 0x41, 0x46, 0x54, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x53,
+	0x50, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x50, 0x52,
+	0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x32, 0x94, 0x03, 0x0a, 0x0a, 0x41, 0x70, 0x70,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e,
+	0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x12,
+	0x33, 0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x1a,
+	0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70,
+	0x70, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70,
+	0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70,
+	0x73, 0x52, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x42,
+	0x1a, 0x5a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x3b,
+	0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_author_app_proto_rawDescOnce sync.Once
+	file_proto_author_app_proto_rawDescData = file_proto_author_app_proto_rawDesc
+)
+
+func file_proto_author_app_proto_rawDescGZIP() []byte {
+	file_proto_author_app_proto_rawDescOnce.Do(func() {
+		file_proto_author_app_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_author_app_proto_rawDescData)
+	})
+	return file_proto_author_app_proto_rawDescData
+}
+
+var file_proto_author_app_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_author_app_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_author_app_proto_goTypes = []interface{}{
+	(AppStatus)(0),              // 0: grpc_author.AppStatus
+	(AppReq_FailPolicy)(0),      // 1: grpc_author.AppReq.FailPolicy
+	(AppRes_Status)(0),          // 2: grpc_author.AppRes.Status
+	(*AppReq)(nil),              // 3: grpc_author.AppReq
+	(*ChangeAppStatusReq)(nil),  // 4: grpc_author.ChangeAppStatusReq
+	(*AppRes)(nil),              // 5: grpc_author.AppRes
+	(*GetAppReq)(nil),           // 6: grpc_author.GetAppReq
+	(*ListAppsReq)(nil),         // 7: grpc_author.ListAppsReq
+	(*ListAppsRes)(nil),         // 8: grpc_author.ListAppsRes
+	(*AppInfo)(nil),             // 9: grpc_author.AppInfo
+	(*AppReq_AppTraffic)(nil),   // 10: grpc_author.AppReq.AppTraffic
+	(*AppReq_Operation)(nil),    // 11: grpc_author.AppReq.Operation
+	(*timestamp.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_proto_author_app_proto_depIdxs = []int32{
+	10, // 0: grpc_author.AppReq.traffics:type_name -> grpc_author.AppReq.AppTraffic
+	11, // 1: grpc_author.AppReq.operations:type_name -> grpc_author.AppReq.Operation
+	1,  // 2: grpc_author.AppReq.fail_policy:type_name -> grpc_author.AppReq.FailPolicy
+	0,  // 3: grpc_author.AppReq.status:type_name -> grpc_author.AppStatus
+	0,  // 4: grpc_author.ChangeAppStatusReq.status:type_name -> grpc_author.AppStatus
+	12, // 5: grpc_author.ChangeAppStatusReq.sunset_at:type_name -> google.protobuf.Timestamp
+	2,  // 6: grpc_author.AppRes.status:type_name -> grpc_author.AppRes.Status
+	9,  // 7: grpc_author.AppRes.app:type_name -> grpc_author.AppInfo
+	2,  // 8: grpc_author.ListAppsRes.status:type_name -> grpc_author.AppRes.Status
+	9,  // 9: grpc_author.ListAppsRes.apps:type_name -> grpc_author.AppInfo
+	1,  // 10: grpc_author.AppInfo.fail_policy:type_name -> grpc_author.AppReq.FailPolicy
+	12, // 11: grpc_author.AppInfo.created_at:type_name -> google.protobuf.Timestamp
+	12, // 12: grpc_author.AppInfo.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 13: grpc_author.AppInfo.deleted_at:type_name -> google.protobuf.Timestamp
+	10, // 14: grpc_author.AppInfo.traffics:type_name -> grpc_author.AppReq.AppTraffic
+	11, // 15: grpc_author.AppInfo.operations:type_name -> grpc_author.AppReq.Operation
+	0,  // 16: grpc_author.AppInfo.status:type_name -> grpc_author.AppStatus
+	12, // 17: grpc_author.AppInfo.sunset_at:type_name -> google.protobuf.Timestamp
+	3,  // 18: grpc_author.AppManager.Create:input_type -> grpc_author.AppReq
+	3,  // 19: grpc_author.AppManager.Update:input_type -> grpc_author.AppReq
+	3,  // 20: grpc_author.AppManager.Destroy:input_type -> grpc_author.AppReq
+	6,  // 21: grpc_author.AppManager.Get:input_type -> grpc_author.GetAppReq
+	7,  // 22: grpc_author.AppManager.List:input_type -> grpc_author.ListAppsReq
+	4,  // 23: grpc_author.AppManager.ChangeStatus:input_type -> grpc_author.ChangeAppStatusReq
+	3,  // 24: grpc_author.AppManager.Restore:input_type -> grpc_author.AppReq
+	5,  // 25: grpc_author.AppManager.Create:output_type -> grpc_author.AppRes
+	5,  // 26: grpc_author.AppManager.Update:output_type -> grpc_author.AppRes
+	5,  // 27: grpc_author.AppManager.Destroy:output_type -> grpc_author.AppRes
+	5,  // 28: grpc_author.AppManager.Get:output_type -> grpc_author.AppRes
+	8,  // 29: grpc_author.AppManager.List:output_type -> grpc_author.ListAppsRes
+	5,  // 30: grpc_author.AppManager.ChangeStatus:output_type -> grpc_author.AppRes
+	5,  // 31: grpc_author.AppManager.Restore:output_type -> grpc_author.AppRes
+	25, // [25:32] is the sub-list for method output_type
+	18, // [18:25] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_proto_author_app_proto_init() }
+func file_proto_author_app_proto_init() {
+	if File_proto_author_app_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_author_app_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeAppStatusReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAppReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAppsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAppsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppReq_AppTraffic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_app_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppReq_Operation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_author_app_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_author_app_proto_goTypes,
+		DependencyIndexes: file_proto_author_app_proto_depIdxs,
+		EnumInfos:         file_proto_author_app_proto_enumTypes,
+		MessageInfos:      file_proto_author_app_proto_msgTypes,
+	}.Build()
+	File_proto_author_app_proto = out.File
+	file_proto_author_app_proto_rawDesc = nil
+	file_proto_author_app_proto_goTypes = nil
+	file_proto_author_app_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AppManagerClient is the client API for AppManager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AppManagerClient interface {
+	Create(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error)
+	Update(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error)
+	Destroy(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error)
+	Get(ctx context.Context, in *GetAppReq, opts ...grpc.CallOption) (*AppRes, error)
+	List(ctx context.Context, in *ListAppsReq, opts ...grpc.CallOption) (*ListAppsRes, error)
+	ChangeStatus(ctx context.Context, in *ChangeAppStatusReq, opts ...grpc.CallOption) (*AppRes, error)
+	// 삭제된 App을 함께 삭제된 operation, traffic과 복구 (app_id만 사용)
+	Restore(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error)
+}
+
+type appManagerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppManagerClient(cc grpc.ClientConnInterface) AppManagerClient {
+	return &appManagerClient{cc}
+}
+
+func (c *appManagerClient) Create(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) Update(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) Destroy(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/Destroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) Get(ctx context.Context, in *GetAppReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) List(ctx context.Context, in *ListAppsReq, opts ...grpc.CallOption) (*ListAppsRes, error) {
+	out := new(ListAppsRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) ChangeStatus(ctx context.Context, in *ChangeAppStatusReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/ChangeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appManagerClient) Restore(ctx context.Context, in *AppReq, opts ...grpc.CallOption) (*AppRes, error) {
+	out := new(AppRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.AppManager/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppManagerServer is the server API for AppManager service.
+type AppManagerServer interface {
+	Create(context.Context, *AppReq) (*AppRes, error)
+	Update(context.Context, *AppReq) (*AppRes, error)
+	Destroy(context.Context, *AppReq) (*AppRes, error)
+	Get(context.Context, *GetAppReq) (*AppRes, error)
+	List(context.Context, *ListAppsReq) (*ListAppsRes, error)
+	ChangeStatus(context.Context, *ChangeAppStatusReq) (*AppRes, error)
+	// 삭제된 App을 함께 삭제된 operation, traffic과 복구 (app_id만 사용)
+	Restore(context.Context, *AppReq) (*AppRes, error)
+}
+
+// UnimplementedAppManagerServer can be embedded to have forward compatible implementations.
+type UnimplementedAppManagerServer struct {
+}
+
+func (*UnimplementedAppManagerServer) Create(context.Context, *AppReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedAppManagerServer) Update(context.Context, *AppReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (*UnimplementedAppManagerServer) Destroy(context.Context, *AppReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (*UnimplementedAppManagerServer) Get(context.Context, *GetAppReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedAppManagerServer) List(context.Context, *ListAppsReq) (*ListAppsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedAppManagerServer) ChangeStatus(context.Context, *ChangeAppStatusReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeStatus not implemented")
+}
+func (*UnimplementedAppManagerServer) Restore(context.Context, *AppReq) (*AppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+
+func RegisterAppManagerServer(s *grpc.Server, srv AppManagerServer) {
+	s.RegisterService(&_AppManager_serviceDesc, srv)
+}
+
+func _AppManager_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).Create(ctx, req.(*AppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).Update(ctx, req.(*AppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/Destroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).Destroy(ctx, req.(*AppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).Get(ctx, req.(*GetAppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).List(ctx, req.(*ListAppsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_ChangeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAppStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).ChangeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/ChangeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).ChangeStatus(ctx, req.(*ChangeAppStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppManager_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppManagerServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.AppManager/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppManagerServer).Restore(ctx, req.(*AppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AppManager_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc_author.AppManager",
+	HandlerType: (*AppManagerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AppManager_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AppManager_Update_Handler,
+		},
+		{
+			MethodName: "Destroy",
+			Handler:    _AppManager_Destroy_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AppManager_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AppManager_List_Handler,
+		},
+		{
+			MethodName: "ChangeStatus",
+			Handler:    _AppManager_ChangeStatus_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _AppManager_Restore_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/author/app.proto",
+}
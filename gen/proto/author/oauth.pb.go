@@ -0,0 +1,509 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.3
+// source: proto/author/oauth.proto
+
+package grpc_author
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type OAuthClientRes_Code int32
+
+const (
+	OAuthClientRes_VALID              OAuthClientRes_Code = 0
+	OAuthClientRes_INVALID_TOKEN      OAuthClientRes_Code = -1
+	OAuthClientRes_PERMISSION_DENIED  OAuthClientRes_Code = -2
+	OAuthClientRes_NOT_FOUND          OAuthClientRes_Code = -3
+	OAuthClientRes_INVALID_REQUEST    OAuthClientRes_Code = -4
+	OAuthClientRes_INTERNAL_EXCEPTION OAuthClientRes_Code = -99
+)
+
+// Enum value maps for OAuthClientRes_Code.
+var (
+	OAuthClientRes_Code_name = map[int32]string{
+		0:   "VALID",
+		-1:  "INVALID_TOKEN",
+		-2:  "PERMISSION_DENIED",
+		-3:  "NOT_FOUND",
+		-4:  "INVALID_REQUEST",
+		-99: "INTERNAL_EXCEPTION",
+	}
+	OAuthClientRes_Code_value = map[string]int32{
+		"VALID":              0,
+		"INVALID_TOKEN":      -1,
+		"PERMISSION_DENIED":  -2,
+		"NOT_FOUND":          -3,
+		"INVALID_REQUEST":    -4,
+		"INTERNAL_EXCEPTION": -99,
+	}
+)
+
+func (x OAuthClientRes_Code) Enum() *OAuthClientRes_Code {
+	p := new(OAuthClientRes_Code)
+	*p = x
+	return p
+}
+
+func (x OAuthClientRes_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OAuthClientRes_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_author_oauth_proto_enumTypes[0].Descriptor()
+}
+
+func (OAuthClientRes_Code) Type() protoreflect.EnumType {
+	return &file_proto_author_oauth_proto_enumTypes[0]
+}
+
+func (x OAuthClientRes_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OAuthClientRes_Code.Descriptor instead.
+func (OAuthClientRes_Code) EnumDescriptor() ([]byte, []int) {
+	return file_proto_author_oauth_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type OAuthClientReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jwt          string   `protobuf:"bytes,1,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	ClientId     string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name         string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	RedirectUris []string `protobuf:"bytes,4,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
+	GrantTypes   []string `protobuf:"bytes,5,rep,name=grant_types,json=grantTypes,proto3" json:"grant_types,omitempty"` // client_credentials, authorization_code, refresh_token
+	Scopes       []string `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	Confidential bool     `protobuf:"varint,7,opt,name=confidential,proto3" json:"confidential,omitempty"`
+}
+
+func (x *OAuthClientReq) Reset() {
+	*x = OAuthClientReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_oauth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OAuthClientReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthClientReq) ProtoMessage() {}
+
+func (x *OAuthClientReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_oauth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthClientReq.ProtoReflect.Descriptor instead.
+func (*OAuthClientReq) Descriptor() ([]byte, []int) {
+	return file_proto_author_oauth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OAuthClientReq) GetJwt() string {
+	if x != nil {
+		return x.Jwt
+	}
+	return ""
+}
+
+func (x *OAuthClientReq) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthClientReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OAuthClientReq) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *OAuthClientReq) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *OAuthClientReq) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuthClientReq) GetConfidential() bool {
+	if x != nil {
+		return x.Confidential
+	}
+	return false
+}
+
+type OAuthClientRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code         OAuthClientRes_Code `protobuf:"varint,1,opt,name=code,proto3,enum=grpc_author.OAuthClientRes_Code" json:"code,omitempty"`
+	ClientId     string              `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string              `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // 등록시에만 반환
+	Msg          string              `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *OAuthClientRes) Reset() {
+	*x = OAuthClientRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_author_oauth_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OAuthClientRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthClientRes) ProtoMessage() {}
+
+func (x *OAuthClientRes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_author_oauth_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthClientRes.ProtoReflect.Descriptor instead.
+func (*OAuthClientRes) Descriptor() ([]byte, []int) {
+	return file_proto_author_oauth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OAuthClientRes) GetCode() OAuthClientRes_Code {
+	if x != nil {
+		return x.Code
+	}
+	return OAuthClientRes_VALID
+}
+
+func (x *OAuthClientRes) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthClientRes) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *OAuthClientRes) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+var File_proto_author_oauth_proto protoreflect.FileDescriptor
+
+var file_proto_author_oauth_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2f, 0x6f,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x22, 0xd5, 0x01, 0x0a, 0x0e, 0x4f, 0x41, 0x75, 0x74,
+	0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x77,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x77, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x72, 0x69, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72,
+	0x69, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22,
+	0xc1, 0x02, 0x0a, 0x0e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x12, 0x34, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x4f,
+	0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x2e, 0x43, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xa4, 0x01, 0x0a,
+	0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
+	0x4e, 0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x12, 0x1e, 0x0a, 0x11,
+	0x50, 0x45, 0x52, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4e, 0x49, 0x45,
+	0x44, 0x10, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x12, 0x16, 0x0a, 0x09,
+	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0xfd, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0x01, 0x12, 0x1c, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xfc, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0x01, 0x12, 0x1f, 0x0a, 0x12, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45,
+	0x58, 0x43, 0x45, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x9d, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0x01, 0x32, 0xa8, 0x01, 0x0a, 0x12, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x0c, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x2e,
+	0x4f, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x42, 0x1a,
+	0x5a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x3b, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_author_oauth_proto_rawDescOnce sync.Once
+	file_proto_author_oauth_proto_rawDescData = file_proto_author_oauth_proto_rawDesc
+)
+
+func file_proto_author_oauth_proto_rawDescGZIP() []byte {
+	file_proto_author_oauth_proto_rawDescOnce.Do(func() {
+		file_proto_author_oauth_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_author_oauth_proto_rawDescData)
+	})
+	return file_proto_author_oauth_proto_rawDescData
+}
+
+var file_proto_author_oauth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_author_oauth_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_author_oauth_proto_goTypes = []interface{}{
+	(OAuthClientRes_Code)(0), // 0: grpc_author.OAuthClientRes.Code
+	(*OAuthClientReq)(nil),   // 1: grpc_author.OAuthClientReq
+	(*OAuthClientRes)(nil),   // 2: grpc_author.OAuthClientRes
+}
+var file_proto_author_oauth_proto_depIdxs = []int32{
+	0, // 0: grpc_author.OAuthClientRes.code:type_name -> grpc_author.OAuthClientRes.Code
+	1, // 1: grpc_author.OAuthClientService.CreateClient:input_type -> grpc_author.OAuthClientReq
+	1, // 2: grpc_author.OAuthClientService.DeleteClient:input_type -> grpc_author.OAuthClientReq
+	2, // 3: grpc_author.OAuthClientService.CreateClient:output_type -> grpc_author.OAuthClientRes
+	2, // 4: grpc_author.OAuthClientService.DeleteClient:output_type -> grpc_author.OAuthClientRes
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_author_oauth_proto_init() }
+func file_proto_author_oauth_proto_init() {
+	if File_proto_author_oauth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_author_oauth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OAuthClientReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_author_oauth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OAuthClientRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_author_oauth_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_author_oauth_proto_goTypes,
+		DependencyIndexes: file_proto_author_oauth_proto_depIdxs,
+		EnumInfos:         file_proto_author_oauth_proto_enumTypes,
+		MessageInfos:      file_proto_author_oauth_proto_msgTypes,
+	}.Build()
+	File_proto_author_oauth_proto = out.File
+	file_proto_author_oauth_proto_rawDesc = nil
+	file_proto_author_oauth_proto_goTypes = nil
+	file_proto_author_oauth_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// OAuthClientServiceClient is the client API for OAuthClientService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OAuthClientServiceClient interface {
+	CreateClient(ctx context.Context, in *OAuthClientReq, opts ...grpc.CallOption) (*OAuthClientRes, error)
+	DeleteClient(ctx context.Context, in *OAuthClientReq, opts ...grpc.CallOption) (*OAuthClientRes, error)
+}
+
+type oAuthClientServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOAuthClientServiceClient(cc grpc.ClientConnInterface) OAuthClientServiceClient {
+	return &oAuthClientServiceClient{cc}
+}
+
+func (c *oAuthClientServiceClient) CreateClient(ctx context.Context, in *OAuthClientReq, opts ...grpc.CallOption) (*OAuthClientRes, error) {
+	out := new(OAuthClientRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.OAuthClientService/CreateClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthClientServiceClient) DeleteClient(ctx context.Context, in *OAuthClientReq, opts ...grpc.CallOption) (*OAuthClientRes, error) {
+	out := new(OAuthClientRes)
+	err := c.cc.Invoke(ctx, "/grpc_author.OAuthClientService/DeleteClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OAuthClientServiceServer is the server API for OAuthClientService service.
+type OAuthClientServiceServer interface {
+	CreateClient(context.Context, *OAuthClientReq) (*OAuthClientRes, error)
+	DeleteClient(context.Context, *OAuthClientReq) (*OAuthClientRes, error)
+}
+
+// UnimplementedOAuthClientServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOAuthClientServiceServer struct {
+}
+
+func (*UnimplementedOAuthClientServiceServer) CreateClient(context.Context, *OAuthClientReq) (*OAuthClientRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClient not implemented")
+}
+func (*UnimplementedOAuthClientServiceServer) DeleteClient(context.Context, *OAuthClientReq) (*OAuthClientRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClient not implemented")
+}
+
+func RegisterOAuthClientServiceServer(s *grpc.Server, srv OAuthClientServiceServer) {
+	s.RegisterService(&_OAuthClientService_serviceDesc, srv)
+}
+
+func _OAuthClientService_CreateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServiceServer).CreateClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.OAuthClientService/CreateClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServiceServer).CreateClient(ctx, req.(*OAuthClientReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthClientService_DeleteClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServiceServer).DeleteClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_author.OAuthClientService/DeleteClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServiceServer).DeleteClient(ctx, req.(*OAuthClientReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OAuthClientService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc_author.OAuthClientService",
+	HandlerType: (*OAuthClientServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateClient",
+			Handler:    _OAuthClientService_CreateClient_Handler,
+		},
+		{
+			MethodName: "DeleteClient",
+			Handler:    _OAuthClientService_DeleteClient_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/author/oauth.proto",
+}
@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/kekim-go/Author/constant"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"github.com/kekim-go/Author/handler"
 	"github.com/kekim-go/Author/model"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
 )
 
 type apiAuthServer struct {
@@ -25,11 +27,21 @@ func (a *apiAuthServer) Auth(ctx context.Context, req *grpc_author.ApiAuthReq) (
 		App: model.App{NameSpace: req.NameSpace, IsDel: false},
 	}
 
-	authCode := a.handler.CheckAppToken(&token, &operation)
+	authCode := a.handler.CheckAppToken(ctx, &token, &operation)
 
 	res := &grpc_author.ApiAuthRes{
 		Code: authCode,
 	}
+	if authCode == grpc_author.ApiAuthRes_VALID && operation.App.CurrentStatus() == constant.AppStatusDeprecated {
+		res.Deprecated = true
+		if operation.App.SunsetAt != nil {
+			sunsetAt, err := ptypes.TimestampProto(*operation.App.SunsetAt)
+			if err != nil {
+				return nil, err
+			}
+			res.SunsetAt = sunsetAt
+		}
+	}
 
 	return res, nil
 }
@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/golang/protobuf/ptypes"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
 	"github.com/kekim-go/Author/model"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,34 +24,59 @@ func newAppManagerServer(appHandler *handler.AppHandler) grpc_author.AppManagerS
 func (a appManagerServer) Create(ctx context.Context, req *grpc_author.AppReq) (*grpc_author.AppRes, error) {
 	app := model.NewAppByGrpc(req)
 
-	if err := a.appHandler.Create(app); err != nil {
-		a.appHandler.Ctx.Logger.WithFields(logrus.Fields{
+	if err := a.appHandler.Create(ctx, app); err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
 			"module":   "appManagerServer",
 			"function": "Create",
 		}).Info(err)
-		return &grpc_author.AppRes{Status: grpc_author.AppRes_ERROR}, nil
+		return a.newWriteErrorRes(ctx, app.Id, err), nil
 	}
 
-	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK}, nil
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK, Version: uint32(app.Version)}, nil
 }
 
 func (a appManagerServer) Update(ctx context.Context, req *grpc_author.AppReq) (*grpc_author.AppRes, error) {
 	app := model.NewAppByGrpc(req)
 
-	if err := a.appHandler.Update(app); err != nil {
-		a.appHandler.Ctx.Logger.WithFields(logrus.Fields{
+	if err := a.appHandler.Update(ctx, app); err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
 			"module":   "appManagerServer",
 			"function": "Update",
 		}).Info(err)
-		return &grpc_author.AppRes{Status: grpc_author.AppRes_ERROR}, nil
+		return a.newWriteErrorRes(ctx, app.Id, err), nil
 	}
 
-	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK}, nil
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK, Version: uint32(app.Version)}, nil
+}
+
+func (a appManagerServer) ChangeStatus(ctx context.Context, req *grpc_author.ChangeAppStatusReq) (*grpc_author.AppRes, error) {
+	app := &model.App{
+		Id:      uint(req.AppId),
+		Status:  model.AppStatusByGrpc(req.Status),
+		Version: int(req.Version),
+	}
+	if req.SunsetAt != nil {
+		sunsetAt, err := ptypes.Timestamp(req.SunsetAt)
+		if err != nil {
+			return &grpc_author.AppRes{Status: grpc_author.AppRes_INVALID_STATUS}, nil
+		}
+		app.SunsetAt = &sunsetAt
+	}
+
+	if err := a.appHandler.ChangeStatus(ctx, app); err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
+			"module":   "appManagerServer",
+			"function": "ChangeStatus",
+		}).Info(err)
+		return a.newWriteErrorRes(ctx, app.Id, err), nil
+	}
+
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK, Version: uint32(app.Version)}, nil
 }
 
 func (a appManagerServer) Destroy(ctx context.Context, req *grpc_author.AppReq) (*grpc_author.AppRes, error) {
-	if err := a.appHandler.Destroy(uint(req.AppId)); err != nil {
-		a.appHandler.Ctx.Logger.WithFields(logrus.Fields{
+	if err := a.appHandler.Destroy(ctx, uint(req.AppId)); err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
 			"module":   "appManagerServer",
 			"function": "Destroy",
 		}).Info(err)
@@ -56,3 +85,102 @@ func (a appManagerServer) Destroy(ctx context.Context, req *grpc_author.AppReq)
 
 	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK}, nil
 }
+
+func (a appManagerServer) Restore(ctx context.Context, req *grpc_author.AppReq) (*grpc_author.AppRes, error) {
+	app, err := a.appHandler.Restore(ctx, uint(req.AppId))
+	if err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
+			"module":   "appManagerServer",
+			"function": "Restore",
+		}).Info(err)
+		return a.newWriteErrorRes(ctx, uint(req.AppId), err), nil
+	}
+
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK, Version: uint32(app.Version)}, nil
+}
+
+func (a appManagerServer) Get(ctx context.Context, req *grpc_author.GetAppReq) (*grpc_author.AppRes, error) {
+	app, usage, err := a.appHandler.FindDetail(ctx, uint(req.AppId), req.IncludeDeleted)
+	if err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound {
+			return &grpc_author.AppRes{Status: grpc_author.AppRes_NOT_FOUND}, nil
+		}
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
+			"module":   "appManagerServer",
+			"function": "Get",
+		}).Info(err)
+		return &grpc_author.AppRes{Status: grpc_author.AppRes_ERROR}, nil
+	}
+
+	info, err := newAppInfo(app, usage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_OK, Version: info.Version, App: info}, nil
+}
+
+func (a appManagerServer) List(ctx context.Context, req *grpc_author.ListAppsReq) (*grpc_author.ListAppsRes, error) {
+	filter := model.AppFilter{
+		NameSpace:      req.NameSpace,
+		IncludeDeleted: req.IncludeDeleted,
+		Page:           int(req.Page),
+		PerPage:        int(req.PerPage),
+	}
+	apps, usages, total, err := a.appHandler.List(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx, a.appHandler.Ctx.Logger).WithFields(logrus.Fields{
+			"module":   "appManagerServer",
+			"function": "List",
+		}).Info(err)
+		return &grpc_author.ListAppsRes{Status: grpc_author.AppRes_ERROR}, nil
+	}
+
+	res := &grpc_author.ListAppsRes{
+		Status:  grpc_author.AppRes_OK,
+		Total:   uint32(total),
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	}
+	for i := range apps {
+		info, err := newAppInfo(&apps[i], usages[apps[i].Id])
+		if err != nil {
+			return nil, err
+		}
+		res.Apps = append(res.Apps, info)
+	}
+
+	return res, nil
+}
+
+func newAppInfo(app *model.App, usage model.AppUsage) (*grpc_author.AppInfo, error) {
+	info, err := app.GetGrpcInfo()
+	if err != nil {
+		return nil, err
+	}
+	info.OperationCount = uint32(usage.OperationCount)
+	info.TokenCount = uint32(usage.TokenCount)
+
+	return info, nil
+}
+
+// 수정 요청의 오류를 응답 상태로 변환
+func (a appManagerServer) newWriteErrorRes(ctx context.Context, appId uint, err error) *grpc_author.AppRes {
+	switch code, _ := errors.Decompose(err); code {
+	case http.StatusNotFound:
+		return &grpc_author.AppRes{Status: grpc_author.AppRes_NOT_FOUND}
+	case http.StatusBadRequest:
+		return &grpc_author.AppRes{Status: grpc_author.AppRes_INVALID_STATUS}
+	case http.StatusPreconditionRequired:
+		return &grpc_author.AppRes{Status: grpc_author.AppRes_INVALID_ARGUMENT}
+	case http.StatusConflict:
+		// 다른 요청이 먼저 수정한 경우 다시 조회할 수 있도록 현재 version 반환
+		res := &grpc_author.AppRes{Status: grpc_author.AppRes_CONFLICT}
+		if current, err := a.appHandler.Find(ctx, appId); err == nil {
+			res.Version = uint32(current.Version)
+		}
+		return res
+	}
+
+	return &grpc_author.AppRes{Status: grpc_author.AppRes_ERROR}
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/kekim-go/Author/constant"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
 	"github.com/kekim-go/Author/model"
 	"github.com/kekim-go/Author/model/relations"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 type authServer struct {
@@ -25,40 +30,178 @@ func newAuthServer(handler *handler.AuthHandler) grpc_author.AuthServiceServer {
 
 func (a *authServer) Login(ctx context.Context, req *grpc_author.LoginReq) (*grpc_author.AuthRes, error) {
 	utr := relations.UserTokenRel{User: model.User{LoginId: req.LoginId}}
+	event := newLoginEvent(ctx, req.LoginId)
 
 	// 회원 조회
-	if err := utr.FindByUserLoginId(a.handler.Ctx.Orm); err != nil {
-		a.handler.Ctx.Logger.Info(err.Error())
+	if err := utr.FindByUserLoginId(ctx, a.handler.Ctx.Orm); err != nil {
+		a.logger(ctx).Info(err.Error())
+		a.handler.RecordLogin(ctx, event, nil, grpc_author.AuthResult_NOT_REGISTERED)
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_NOT_REGISTERED}, nil
 	}
 
 	// 비밀번호 확인
-	if _, err := model.ComparePasswords(utr.User.Password, req.Password); err != nil {
-		a.handler.Ctx.Logger.Debug(err.Error())
+	if _, err := a.handler.Ctx.PasswordHasher.Compare(utr.User.Password, req.Password); err != nil {
+		a.logger(ctx).Debug(err.Error())
+		a.handler.RecordLogin(ctx, event, &utr.User, grpc_author.AuthResult_INVALID_PASSWORD)
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INVALID_PASSWORD}, nil
 	}
 
-	a.handler.Ctx.Logger.WithFields(logrus.Fields{
-		"UserTokenRel": fmt.Sprintf("%+v", utr),
-	}).Debug("Token Info")
+	// 약한 파라미터로 저장된 해시는 현재 설정으로 재암호화
+	a.handler.RehashPassword(ctx, &utr.User, req.Password)
 
-	if utr.Token.Id == 0 || utr.Token.JwtExpiredAt == nil || time.Now().After(*utr.Token.JwtExpiredAt) {
-		authRes := a.genTokens(&utr)
+	return a.completeLogin(ctx, event, &utr)
+}
 
-		if authRes != nil {
-			return authRes, nil
+// FederatedLogin 외부 IdP의 ID 토큰으로 로그인, 2단계 인증 사용 회원은 Login과 동일하게 MFA_REQUIRED 응답
+func (a *authServer) FederatedLogin(ctx context.Context, req *grpc_author.FederatedLoginReq) (*grpc_author.AuthRes, error) {
+	event := newLoginEvent(ctx, "")
+
+	user, err := a.handler.FederatedLogin(ctx, req.IdToken)
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+
+		res := &grpc_author.AuthRes{}
+		switch code, msg := errors.Decompose(err); code {
+		case http.StatusUnauthorized:
+			res.Code = grpc_author.AuthResult_INVALID_TOKEN
+		case http.StatusForbidden:
+			res.Code = grpc_author.AuthResult_UNTRUSTED_ISSUER
+		case http.StatusNotFound, http.StatusBadRequest, http.StatusConflict:
+			res.Code = grpc_author.AuthResult_NOT_REGISTERED
+			res.Msg = msg
+		default:
+			res.Code = grpc_author.AuthResult_INTERNAL_EXCEPTION
 		}
+
+		a.handler.RecordLogin(ctx, event, nil, res.Code)
+		return res, nil
 	}
+	event.LoginId = user.LoginId
 
-	return utr.Token.GetValidGrpcRes()
+	utr := relations.UserTokenRel{User: model.User{LoginId: user.LoginId}}
+	if err := utr.FindByUserLoginId(ctx, a.handler.Ctx.Orm); err != nil {
+		a.logger(ctx).Info(err.Error())
+		a.handler.RecordLogin(ctx, event, user, grpc_author.AuthResult_NOT_REGISTERED)
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_NOT_REGISTERED}, nil
+	}
+
+	return a.completeLogin(ctx, event, &utr)
+}
+
+func (a *authServer) VerifyMfa(ctx context.Context, req *grpc_author.VerifyMfaReq) (*grpc_author.AuthRes, error) {
+	userId, err := a.handler.FindMfaChallenge(ctx, req.MfaToken)
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+		if code, _ := errors.Decompose(err); code == http.StatusUnauthorized {
+			return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INVALID_TOKEN}, nil
+		}
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
+	}
+
+	user := model.User{Id: userId}
+	if err := user.Find(ctx, a.handler.Ctx.Orm); err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_NOT_REGISTERED}, nil
+	}
+
+	utr := relations.UserTokenRel{User: model.User{LoginId: user.LoginId}}
+	if err := utr.FindByUserLoginId(ctx, a.handler.Ctx.Orm); err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_NOT_REGISTERED}, nil
+	}
+
+	event := newLoginEvent(ctx, utr.User.LoginId)
+	if err := a.handler.VerifyMfaCode(ctx, &utr.User, req.Code); err != nil {
+		a.logger(ctx).Info(err.Error())
+		a.handler.FailMfaChallenge(ctx, req.MfaToken)
+
+		code := mfaResultCode(err)
+		a.handler.RecordLogin(ctx, event, &utr.User, code)
+		return &grpc_author.AuthRes{Code: code}, nil
+	}
+	a.handler.DeleteMfaChallenge(ctx, req.MfaToken)
+
+	return a.issueTokens(ctx, event, &utr)
+}
+
+func (a *authServer) EnrollMfa(ctx context.Context, req *grpc_author.MfaReq) (*grpc_author.MfaRes, error) {
+	user, code := a.findUserByJwt(ctx, req.Jwt)
+	if code != grpc_author.AuthResult_VALID {
+		return &grpc_author.MfaRes{Code: code}, nil
+	}
+
+	mfa, err := a.handler.EnrollMfa(ctx, user)
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.MfaRes{Code: mfaResultCode(err)}, nil
+	}
+
+	return &grpc_author.MfaRes{
+		Code:       grpc_author.AuthResult_VALID,
+		Secret:     mfa.Secret,
+		OtpauthUri: a.handler.MfaURI(user, mfa),
+	}, nil
+}
+
+func (a *authServer) ConfirmMfa(ctx context.Context, req *grpc_author.MfaReq) (*grpc_author.MfaRes, error) {
+	user, code := a.findUserByJwt(ctx, req.Jwt)
+	if code != grpc_author.AuthResult_VALID {
+		return &grpc_author.MfaRes{Code: code}, nil
+	}
+
+	recoveryCodes, err := a.handler.ConfirmMfa(ctx, user, req.Code)
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.MfaRes{Code: mfaResultCode(err)}, nil
+	}
+
+	return &grpc_author.MfaRes{Code: grpc_author.AuthResult_VALID, RecoveryCodes: recoveryCodes}, nil
+}
+
+func (a *authServer) DisableMfa(ctx context.Context, req *grpc_author.MfaReq) (*grpc_author.MfaRes, error) {
+	user, code := a.findUserByJwt(ctx, req.Jwt)
+	if code != grpc_author.AuthResult_VALID {
+		return &grpc_author.MfaRes{Code: code}, nil
+	}
+
+	if err := a.handler.DisableMfa(ctx, user, req.Code); err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.MfaRes{Code: mfaResultCode(err)}, nil
+	}
+
+	return &grpc_author.MfaRes{Code: grpc_author.AuthResult_VALID}, nil
+}
+
+func (a *authServer) LoginHistory(ctx context.Context, req *grpc_author.LoginHistoryReq) (*grpc_author.LoginHistoryRes, error) {
+	user, code := a.findUserByJwt(ctx, req.Jwt)
+	if code != grpc_author.AuthResult_VALID {
+		return &grpc_author.LoginHistoryRes{Code: code}, nil
+	}
+
+	events, err := model.FindLoginEventsByUser(ctx, a.handler.Ctx.Orm, user.Id, int(req.Limit))
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+		return &grpc_author.LoginHistoryRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
+	}
+
+	res := &grpc_author.LoginHistoryRes{Code: grpc_author.AuthResult_VALID}
+	for _, event := range events {
+		grpcEvent, err := event.GetGrpcEvent()
+		if err != nil {
+			return nil, err
+		}
+		res.Events = append(res.Events, grpcEvent)
+	}
+
+	return res, nil
 }
 
 func (a *authServer) Auth(ctx context.Context, req *grpc_author.JwtReq) (*grpc_author.AuthRes, error) {
 	ut := model.UserToken{Jwt: req.Jwt}
-	err := ut.FindUserToken(a.handler.Ctx.Orm)
+	err := ut.FindUserToken(ctx, a.handler.Ctx.Orm)
 
 	if err != nil {
-		a.handler.Ctx.Logger.Info(err.Error())
+		a.logger(ctx).Info(err.Error())
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
 	}
 
@@ -71,10 +214,10 @@ func (a *authServer) Auth(ctx context.Context, req *grpc_author.JwtReq) (*grpc_a
 
 func (a *authServer) Refresh(ctx context.Context, req *grpc_author.RefreshTokenReq) (*grpc_author.AuthRes, error) {
 	utr := relations.UserTokenRel{Token: model.UserToken{RefreshToken: req.RefreshToken}}
-	err := utr.FindByRefreshToken(a.handler.Ctx.Orm)
+	err := utr.FindByRefreshToken(ctx, a.handler.Ctx.Orm)
 
 	if err != nil {
-		a.handler.Ctx.Logger.Info(err.Error())
+		a.logger(ctx).Info(err.Error())
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
 	}
 
@@ -82,7 +225,7 @@ func (a *authServer) Refresh(ctx context.Context, req *grpc_author.RefreshTokenR
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INVALID_TOKEN}, nil
 	}
 
-	authRes := a.genTokens(&utr)
+	authRes := a.genTokens(ctx, &utr)
 
 	if authRes != nil {
 		return authRes, nil
@@ -91,16 +234,72 @@ func (a *authServer) Refresh(ctx context.Context, req *grpc_author.RefreshTokenR
 	return utr.Token.GetValidGrpcRes()
 }
 
-func (a *authServer) genRefreshToken() (string, error) {
+// 1차 인증을 마친 회원에게 JWT 발급
+// 2단계 인증 사용 회원은 인증 대기 토큰만 발급하고, VerifyMfa에서 JWT 발급
+func (a *authServer) completeLogin(ctx context.Context, event *model.LoginEvent, utr *relations.UserTokenRel) (*grpc_author.AuthRes, error) {
+	if enabled, err := model.IsMfaEnabled(ctx, a.handler.Ctx.Orm, utr.User.Id); err != nil {
+		a.logger(ctx).Info(err.Error())
+		a.handler.RecordLogin(ctx, event, &utr.User, grpc_author.AuthResult_INTERNAL_EXCEPTION)
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
+	} else if enabled {
+		mfaToken, err := a.handler.NewMfaChallenge(ctx, &utr.User)
+		if err != nil {
+			a.logger(ctx).Info(err.Error())
+			a.handler.RecordLogin(ctx, event, &utr.User, grpc_author.AuthResult_INTERNAL_EXCEPTION)
+			return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}, nil
+		}
+
+		a.handler.RecordLogin(ctx, event, &utr.User, grpc_author.AuthResult_MFA_REQUIRED)
+		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_MFA_REQUIRED, MfaToken: mfaToken}, nil
+	}
+
+	return a.issueTokens(ctx, event, utr)
+}
+
+// 비밀번호(및 2단계) 인증을 마친 회원에게 JWT/Refresh Token 발급
+func (a *authServer) issueTokens(ctx context.Context, event *model.LoginEvent, utr *relations.UserTokenRel) (*grpc_author.AuthRes, error) {
+	a.logger(ctx).WithFields(logrus.Fields{
+		"userId":  utr.User.Id,
+		"tokenId": utr.Token.Id,
+	}).Debug("Token Info")
+
+	if utr.Token.Id == 0 || utr.Token.JwtExpiredAt == nil || time.Now().After(*utr.Token.JwtExpiredAt) {
+		authRes := a.genTokens(ctx, utr)
+
+		if authRes != nil {
+			a.handler.RecordLogin(ctx, event, &utr.User, authRes.Code)
+			return authRes, nil
+		}
+	}
+
+	a.handler.RecordLogin(ctx, event, &utr.User, grpc_author.AuthResult_VALID)
+
+	return utr.Token.GetValidGrpcRes()
+}
+
+// JWT에 해당하는 회원 조회, 실패시 응답 코드 반환
+func (a *authServer) findUserByJwt(ctx context.Context, jwt string) (*model.User, grpc_author.AuthResult) {
+	user, err := model.FindUserByJwt(ctx, a.handler.Ctx.Orm, jwt)
+	if err != nil {
+		a.logger(ctx).Info(err.Error())
+		if code, _ := errors.Decompose(err); code == http.StatusUnauthorized || code == http.StatusNotFound {
+			return nil, grpc_author.AuthResult_INVALID_TOKEN
+		}
+		return nil, grpc_author.AuthResult_INTERNAL_EXCEPTION
+	}
+
+	return user, grpc_author.AuthResult_VALID
+}
+
+func (a *authServer) genRefreshToken(ctx context.Context) (string, error) {
 	for {
 		b := make([]byte, 32)
 		rand.Read(b)
 		refreshToken := fmt.Sprintf("%x", b)
-		a.handler.Ctx.Logger.Debug(refreshToken)
 
-		has, err := model.CheckRefreshToken(a.handler.Ctx.Orm, refreshToken)
+		has, err := model.CheckRefreshToken(ctx, a.handler.Ctx.Orm, refreshToken)
 		if err != nil {
-			a.handler.Ctx.Logger.Debug(err)
+			a.logger(ctx).Debug(err)
 			return "", err
 		}
 		if !has {
@@ -109,7 +308,7 @@ func (a *authServer) genRefreshToken() (string, error) {
 	}
 }
 
-func (a *authServer) genTokens(utr *relations.UserTokenRel) *grpc_author.AuthRes {
+func (a *authServer) genTokens(ctx context.Context, utr *relations.UserTokenRel) *grpc_author.AuthRes {
 	utr.Token.UserId = utr.User.Id
 
 	// JWT 만료 시간 설정
@@ -122,35 +321,73 @@ func (a *authServer) genTokens(utr *relations.UserTokenRel) *grpc_author.AuthRes
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	jwt, err := token.SignedString([]byte(constant.JwtSecret))
+	jwt, err := model.SignTokenClaims(claims)
 	if err != nil {
 		// If there is an error in creating the JWT return an internal server error
-		a.handler.Ctx.Logger.Info(err.Error())
+		a.logger(ctx).Info(err.Error())
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}
 	}
-	a.handler.Ctx.Logger.Debug(jwt)
 	utr.Token.Jwt = jwt
 	utr.Token.JwtExpiredAt = &jwtExp
 
 	if len(utr.Token.RefreshToken) == 0 || time.Now().After(*utr.Token.RefreshTokenExpiredAt) {
-		refreshToken, err := a.genRefreshToken()
+		refreshToken, err := a.genRefreshToken(ctx)
 		if err != nil {
-			a.handler.Ctx.Logger.Info(err.Error())
+			a.logger(ctx).Info(err.Error())
 			return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}
 		}
 
 		utr.Token.SetRefreshToken(refreshToken)
 	}
 
-	if err := utr.Token.Save(a.handler.Ctx.Orm); err != nil {
-		a.handler.Ctx.Logger.Info(err.Error())
+	if err := utr.Token.Save(ctx, a.handler.Ctx.Orm); err != nil {
+		a.logger(ctx).Info(err.Error())
 		return &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}
 	}
 
-	a.handler.Ctx.Logger.WithFields(logrus.Fields{
-		"token detail": fmt.Sprintf("%+v", utr.Token),
-	}).Debug("Token Info")
+	a.logger(ctx).WithFields(logrus.Fields{
+		"userId":       utr.User.Id,
+		"tokenId":      utr.Token.Id,
+		"jwtExpiredAt": utr.Token.JwtExpiredAt,
+	}).Debug("Token issued")
 
 	return nil
 }
+
+// 요청 ID가 포함된 logger
+func (a *authServer) logger(ctx context.Context) *logrus.Entry {
+	return logging.FromContext(ctx, a.handler.Ctx.Logger)
+}
+
+// 요청의 peer 주소 및 user-agent 메타데이터로 로그인 이력 생성
+func newLoginEvent(ctx context.Context, loginId string) *model.LoginEvent {
+	event := &model.LoginEvent{LoginId: loginId}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.RemoteAddr = p.Addr.String()
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+			event.UserAgent = userAgent[0]
+		}
+	}
+
+	return event
+}
+
+// 2단계 인증 처리 오류를 응답 코드로 변환
+func mfaResultCode(err error) grpc_author.AuthResult {
+	switch code, _ := errors.Decompose(err); code {
+	case http.StatusUnauthorized:
+		return grpc_author.AuthResult_INVALID_MFA_CODE
+	case http.StatusNotFound:
+		return grpc_author.AuthResult_MFA_NOT_ENROLLED
+	case http.StatusConflict:
+		return grpc_author.AuthResult_MFA_ALREADY_ENABLED
+	case http.StatusTooManyRequests:
+		return grpc_author.AuthResult_MFA_LOCKED
+	default:
+		return grpc_author.AuthResult_INTERNAL_EXCEPTION
+	}
+}
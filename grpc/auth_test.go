@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/model"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// 로그인 시도는 요청 주소, user-agent와 함께 기록되고 LoginHistory로 본인 이력만 최신순 조회
+func TestLoginHistory(t *testing.T) {
+	c := newTestContext(t)
+	s := newAuthServer(handler.NewAuthHandler(c))
+
+	enc, err := c.PasswordHasher.Hash("password1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &model.User{LoginId: "alice", Email: "alice@example.com", Name: "alice", Password: enc}
+	if _, err := c.Orm.Insert(user); err != nil {
+		t.Fatal(err)
+	}
+	expiredAt := time.Now().Add(time.Hour)
+	if _, err := c.Orm.Insert(&model.UserToken{UserId: user.Id, Jwt: "jwt", RefreshToken: "refresh", JwtExpiredAt: &expiredAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	background := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "test-agent"))
+	background = peer.NewContext(background, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	if res, err := s.Login(background, &grpc_author.LoginReq{LoginId: "alice", Password: "wrong"}); err != nil || res.Code != grpc_author.AuthResult_INVALID_PASSWORD {
+		t.Fatalf("Login = %v, %v", res, err)
+	}
+	if res, err := s.Login(background, &grpc_author.LoginReq{LoginId: "nobody", Password: "wrong"}); err != nil || res.Code != grpc_author.AuthResult_NOT_REGISTERED {
+		t.Fatalf("Login = %v, %v", res, err)
+	}
+	handler.NewAuthHandler(c).RecordLogin(background, &model.LoginEvent{LoginId: "alice"}, user, grpc_author.AuthResult_VALID)
+
+	res, err := s.LoginHistory(background, &grpc_author.LoginHistoryReq{Jwt: "jwt", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Code != grpc_author.AuthResult_VALID || len(res.Events) != 2 {
+		t.Fatalf("LoginHistory = %+v", res)
+	}
+	if res.Events[0].Result != grpc_author.AuthResult_VALID || res.Events[1].Result != grpc_author.AuthResult_INVALID_PASSWORD {
+		t.Errorf("events = %+v", res.Events)
+	}
+	if failed := res.Events[1]; failed.LoginId != "alice" || failed.RemoteAddr != "10.0.0.1:5000" || failed.UserAgent != "test-agent" {
+		t.Errorf("failed login event = %+v", failed)
+	}
+
+	if res, err := s.LoginHistory(background, &grpc_author.LoginHistoryReq{Jwt: "unknown"}); err != nil || res.Code != grpc_author.AuthResult_INVALID_TOKEN {
+		t.Errorf("LoginHistory with unknown jwt = %v, %v", res, err)
+	}
+}
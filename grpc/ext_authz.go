@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	auth "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/handler"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+)
+
+// extAuthzServer Envoy external authorization(envoy.service.auth.v3.Authorization) 구현
+type extAuthzServer struct {
+	handler *handler.AppTokenHandler
+}
+
+func newExtAuthzServer(handler *handler.AppTokenHandler) auth.AuthorizationServer {
+	return &extAuthzServer{handler: handler}
+}
+
+func (e *extAuthzServer) Check(ctx context.Context, req *auth.CheckRequest) (*auth.CheckResponse, error) {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+
+	res := e.handler.ExtAuthorize(ctx, handler.ExtAuthzReq{
+		Host:    httpReq.GetHost(),
+		Path:    httpReq.GetPath(),
+		Headers: httpReq.GetHeaders(),
+	})
+
+	headers := newHeaderValueOptions(res.Headers)
+	if res.Code == grpc_author.ApiAuthRes_VALID {
+		return &auth.CheckResponse{
+			Status:       &status.Status{Code: int32(codes.OK)},
+			HttpResponse: &auth.CheckResponse_OkResponse{OkResponse: &auth.OkHttpResponse{Headers: headers}},
+		}, nil
+	}
+
+	code := codes.PermissionDenied
+	switch res.Status {
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	}
+
+	return &auth.CheckResponse{
+		Status: &status.Status{Code: int32(code), Message: res.Code.String()},
+		HttpResponse: &auth.CheckResponse_DeniedResponse{DeniedResponse: &auth.DeniedHttpResponse{
+			Status:  &envoy_type.HttpStatus{Code: envoy_type.StatusCode(res.Status)},
+			Headers: headers,
+		}},
+	}, nil
+}
+
+func newHeaderValueOptions(headers map[string]string) []*core.HeaderValueOption {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	options := make([]*core.HeaderValueOption, 0, len(keys))
+	for _, key := range keys {
+		options = append(options, &core.HeaderValueOption{Header: &core.HeaderValue{Key: key, Value: headers[key]}})
+	}
+
+	return options
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/kekim-go/Author/constant"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// watchHealth DB, Redis 연결 상태를 주기적으로 확인하여 전체 서비스("")의 상태로 반영
+// 종료 신호 수신 후에는 healthServer.Shutdown으로 NOT_SERVING이 유지되도록 확인을 중단
+func (s *Server) watchHealth(healthServer *health.Server) {
+	ticker := time.NewTicker(constant.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		healthServer.SetServingStatus("", s.checkHealth())
+
+		select {
+		case <-s.context.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) checkHealth() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	logger := s.ctx.Logger.WithFields(logrus.Fields{
+		"module":   "Server",
+		"function": "checkHealth",
+	})
+
+	// 확인이 다음 주기까지 지연되지 않도록 제한
+	ctx, cancel := context.WithTimeout(context.Background(), constant.HealthCheckInterval)
+	defer cancel()
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if err := s.ctx.Orm.PingContext(ctx); err != nil {
+		logger.Warn("database is not ready: ", err)
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	if err := s.ctx.RedisDB.Ping(ctx); err != nil {
+		logger.Warn("redis is not ready: ", err)
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return status
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/database"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// 모든 명령에 PONG으로 응답하는 Redis 대역, 연결 가능 여부만 확인하는 health check용
+func newPongRedis(t *testing.T) *database.RedisDB {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					// 배열 헤더(*n)를 만나면 명령 하나로 보고 응답
+					if strings.HasPrefix(line, "*") {
+						conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: l.Addr().String(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	return database.NewRedisDB(client, time.Second, nil)
+}
+
+func servingStatus(t *testing.T, healthServer *health.Server) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	res, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res.Status
+}
+
+// DB, Redis 중 하나라도 연결되지 않으면 NOT_SERVING
+func TestWatchHealth(t *testing.T) {
+	c := newTestContext(t)
+	unreachable := c.RedisDB
+	c.RedisDB = newPongRedis(t)
+
+	// 종료된 context로 한 번만 확인하고 반환
+	serverContext, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := New(c, serverContext)
+	healthServer := health.NewServer()
+
+	s.watchHealth(healthServer)
+	if status := servingStatus(t, healthServer); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("db and redis available: %v, want SERVING", status)
+	}
+
+	c.RedisDB = unreachable
+	s.watchHealth(healthServer)
+	if status := servingStatus(t, healthServer); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("redis unavailable: %v, want NOT_SERVING", status)
+	}
+
+	c.RedisDB = newPongRedis(t)
+	s.watchHealth(healthServer)
+	if status := servingStatus(t, healthServer); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("redis recovered: %v, want SERVING", status)
+	}
+
+	c.Orm.Close()
+	s.watchHealth(healthServer)
+	if status := servingStatus(t, healthServer); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("db closed: %v, want NOT_SERVING", status)
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kekim-go/Author/constant"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+	"github.com/sirupsen/logrus"
+)
+
+type oauthClientServer struct {
+	handler *handler.OAuthHandler
+}
+
+func newOAuthClientServer(handler *handler.OAuthHandler) grpc_author.OAuthClientServiceServer {
+	return &oauthClientServer{handler: handler}
+}
+
+func (o *oauthClientServer) CreateClient(ctx context.Context, req *grpc_author.OAuthClientReq) (*grpc_author.OAuthClientRes, error) {
+	admin, code := o.findAdminByJwt(ctx, req.Jwt)
+	if code != grpc_author.OAuthClientRes_VALID {
+		return &grpc_author.OAuthClientRes{Code: code}, nil
+	}
+
+	client := &model.OAuthClient{
+		Name:         req.Name,
+		RedirectUris: req.RedirectUris,
+		GrantTypes:   req.GrantTypes,
+		Scopes:       req.Scopes,
+		UserId:       admin.Id,
+	}
+
+	secret, err := o.handler.CreateClient(ctx, client, req.Confidential)
+	if err != nil {
+		o.logError(ctx, "CreateClient", err)
+		if status, msg := errors.Decompose(err); status == http.StatusBadRequest {
+			return &grpc_author.OAuthClientRes{Code: grpc_author.OAuthClientRes_INVALID_REQUEST, Msg: msg}, nil
+		}
+		return &grpc_author.OAuthClientRes{Code: grpc_author.OAuthClientRes_INTERNAL_EXCEPTION}, nil
+	}
+
+	return &grpc_author.OAuthClientRes{
+		Code:         grpc_author.OAuthClientRes_VALID,
+		ClientId:     client.ClientId,
+		ClientSecret: secret,
+	}, nil
+}
+
+func (o *oauthClientServer) DeleteClient(ctx context.Context, req *grpc_author.OAuthClientReq) (*grpc_author.OAuthClientRes, error) {
+	if _, code := o.findAdminByJwt(ctx, req.Jwt); code != grpc_author.OAuthClientRes_VALID {
+		return &grpc_author.OAuthClientRes{Code: code}, nil
+	}
+
+	if err := o.handler.DeleteClient(ctx, req.ClientId); err != nil {
+		o.logError(ctx, "DeleteClient", err)
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+			return &grpc_author.OAuthClientRes{Code: grpc_author.OAuthClientRes_NOT_FOUND}, nil
+		}
+		return &grpc_author.OAuthClientRes{Code: grpc_author.OAuthClientRes_INTERNAL_EXCEPTION}, nil
+	}
+
+	return &grpc_author.OAuthClientRes{Code: grpc_author.OAuthClientRes_VALID, ClientId: req.ClientId}, nil
+}
+
+func (o *oauthClientServer) findAdminByJwt(ctx context.Context, jwt string) (*model.User, grpc_author.OAuthClientRes_Code) {
+	user, err := model.FindUserByJwt(ctx, o.handler.Ctx.Orm, jwt)
+	if err != nil {
+		o.logError(ctx, "findAdminByJwt", err)
+		if status, _ := errors.Decompose(err); status == http.StatusUnauthorized || status == http.StatusNotFound {
+			return nil, grpc_author.OAuthClientRes_INVALID_TOKEN
+		}
+		return nil, grpc_author.OAuthClientRes_INTERNAL_EXCEPTION
+	}
+
+	isAdmin, err := model.HasRole(ctx, o.handler.Ctx.Orm, user.Id, constant.RoleAdmin)
+	if err != nil {
+		o.logError(ctx, "findAdminByJwt", err)
+		return nil, grpc_author.OAuthClientRes_INTERNAL_EXCEPTION
+	}
+	if !isAdmin {
+		return nil, grpc_author.OAuthClientRes_PERMISSION_DENIED
+	}
+
+	return user, grpc_author.OAuthClientRes_VALID
+}
+
+func (o *oauthClientServer) logError(ctx context.Context, function string, err error) {
+	logging.FromContext(ctx, o.handler.Ctx.Logger).WithFields(logrus.Fields{
+		"module":   "oauthClientServer",
+		"function": function,
+	}).Info(err)
+}
@@ -3,13 +3,21 @@ package server
 import (
 	"context"
 	"net"
+	"time"
 
+	auth "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/kekim-go/Author/app/ctx"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"github.com/kekim-go/Author/handler"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/metrics"
+	"github.com/kekim-go/Author/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 // Server is an main application object that shared (read-only) to application modules
@@ -19,6 +27,27 @@ type Server struct {
 	grpcServer *grpc.Server
 }
 
+// Services gRPC 서비스 구현, HTTP 게이트웨이에서도 같은 구현을 사용
+type Services struct {
+	ApiAuth     grpc_author.ApiAuthServiceServer
+	AppManager  grpc_author.AppManagerServer
+	Auth        grpc_author.AuthServiceServer
+	User        grpc_author.UserServiceServer
+	OAuthClient grpc_author.OAuthClientServiceServer
+	ExtAuthz    auth.AuthorizationServer
+}
+
+func NewServices(c *ctx.Context) *Services {
+	return &Services{
+		ApiAuth:     newApiAuthServer(handler.NewAppTokenHandler(c)),
+		AppManager:  newAppManagerServer(handler.NewAppHandler(c)),
+		Auth:        newAuthServer(handler.NewAuthHandler(c)),
+		User:        newUserServer(handler.NewUserHandler(c)),
+		OAuthClient: newOAuthClientServer(handler.NewOAuthHandler(c)),
+		ExtAuthz:    newExtAuthzServer(handler.NewAppTokenHandler(c)),
+	}
+}
+
 // New constructor
 func New(c *ctx.Context, context context.Context) *Server {
 	s := new(Server)
@@ -26,6 +55,9 @@ func New(c *ctx.Context, context context.Context) *Server {
 	s.context = context
 	s.grpcServer = grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			tracing.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(c.Logger),
+			metrics.UnaryServerInterceptor(),
 			grpc_recovery.UnaryServerInterceptor(),
 		)),
 	)
@@ -39,25 +71,51 @@ func (s *Server) Run(network, address string) error {
 		return err
 	}
 
-	appTokenHandler := handler.NewAppTokenHandler(s.ctx)
-	appHandler := handler.NewAppHandler(s.ctx)
-	authHandler := handler.NewAuthHandler(s.ctx)
-	userHandler := handler.NewUserHandler(s.ctx)
+	services := NewServices(s.ctx)
 
 	// Token 기반의 인증 처리
-	grpc_author.RegisterApiAuthServiceServer(s.grpcServer, newApiAuthServer(appTokenHandler))
+	grpc_author.RegisterApiAuthServiceServer(s.grpcServer, services.ApiAuth)
+	auth.RegisterAuthorizationServer(s.grpcServer, services.ExtAuthz)
+
+	grpc_author.RegisterAppManagerServer(s.grpcServer, services.AppManager)
+
+	grpc_author.RegisterAuthServiceServer(s.grpcServer, services.Auth)
+	grpc_author.RegisterUserServiceServer(s.grpcServer, services.User)
+	grpc_author.RegisterOAuthClientServiceServer(s.grpcServer, services.OAuthClient)
 
-	grpc_author.RegisterAppManagerServer(s.grpcServer, newAppManagerServer(appHandler))
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(s.grpcServer, healthServer)
+	go s.watchHealth(healthServer)
 
-	grpc_author.RegisterAuthServiceServer(s.grpcServer, newAuthServer(authHandler))
-	grpc_author.RegisterUserServiceServer(s.grpcServer, newUserServer(userHandler))
+	if s.ctx.Config.ServerConfig.Reflection {
+		reflection.Register(s.grpcServer)
+	}
 
 	go func() {
-		defer s.grpcServer.GracefulStop()
 		<-s.context.Done()
+		healthServer.Shutdown()
+		s.stop()
 	}()
 
 	s.ctx.Logger.Info("start gRPC grpc at ", address)
 	return s.grpcServer.Serve(l)
+}
 
+// 처리 중인 요청이 끝나기를 기다리되, 종료 대기 시간이 지나면 남은 연결을 강제로 종료
+func (s *Server) stop() {
+	timeout := s.ctx.Config.ServerConfig.GetShutdownTimeout()
+	s.ctx.Logger.Info("stop gRPC server, waiting up to ", timeout)
+
+	done := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.ctx.Logger.Warn("gRPC graceful stop timed out, closing remaining connections")
+		s.grpcServer.Stop()
+	}
 }
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	"github.com/kekim-go/Author/migration"
+	"github.com/kekim-go/Author/policy"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLite DB와 연결되지 않는 Redis를 사용하는 테스트 Context
+func newTestContext(t *testing.T) *ctx.Context {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	entry := logrus.NewEntry(logger)
+
+	orm, err := xorm.NewEngine(ctx.DBSqlite, filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { orm.Close() })
+
+	if err := migration.New(orm, entry).Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	hasher, err := policy.NewPasswordHasher(policy.PasswordHashConfig{BcryptCost: bcrypt.MinCost})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ctx.Context{
+		Mode:           constant.ServiceDev,
+		Logger:         entry,
+		Orm:            orm,
+		RedisDB:        database.NewRedisDB(client, 100*time.Millisecond, nil),
+		Config:         &ctx.Config{},
+		PasswordHasher: hasher,
+	}
+}
+
+// 종료 대기 시간 안에 끝나지 않는 스트림이 있으면 Stop으로 강제 종료
+func TestStopTimeout(t *testing.T) {
+	c := newTestContext(t)
+	c.Config.ServerConfig.ShutdownTimeout = 1
+	s := New(c, context.Background())
+
+	grpc_health_v1.RegisterHealthServer(s.grpcServer, health.NewServer())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.grpcServer.Serve(l)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Watch 스트림은 클라이언트가 끊기 전까지 유지되어 GracefulStop이 끝나지 않음
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan struct{})
+	start := time.Now()
+	go func() {
+		s.stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop did not return after shutdown timeout")
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("stop returned after %v, want to wait for shutdown timeout", elapsed)
+	}
+	if _, err := stream.Recv(); err == nil {
+		t.Error("stream still open after stop")
+	}
+}
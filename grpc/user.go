@@ -2,10 +2,13 @@ package server
 
 import (
 	"context"
+	"net/http"
 
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"github.com/kekim-go/Author/handler"
 	"github.com/kekim-go/Author/model"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
+	"github.com/kekim-go/Author/policy"
 )
 
 type userServer struct {
@@ -23,7 +26,13 @@ func (s userServer) Signup(ctx context.Context, req *grpc_author.UserReq) (*grpc
 		}, nil
 	}
 
-	if has, err := model.CheckLoginId(s.handler.Ctx.Orm, req.LoginId); err != nil {
+	if violations, err := s.handler.ValidatePassword(ctx, nil, req.Password); err != nil {
+		return nil, err
+	} else if len(violations) > 0 {
+		return newViolationRes(violations), nil
+	}
+
+	if has, err := model.CheckLoginId(ctx, s.handler.Ctx.Orm, req.LoginId); err != nil {
 		return nil, err
 	} else if has {
 		return &grpc_author.UserRes{
@@ -31,7 +40,7 @@ func (s userServer) Signup(ctx context.Context, req *grpc_author.UserReq) (*grpc
 		}, nil
 	}
 
-	if has, err := model.CheckEmail(s.handler.Ctx.Orm, req.Email); err != nil {
+	if has, err := model.CheckEmail(ctx, s.handler.Ctx.Orm, req.Email); err != nil {
 		return nil, err
 	} else if has {
 		return &grpc_author.UserRes{
@@ -46,16 +55,136 @@ func (s userServer) Signup(ctx context.Context, req *grpc_author.UserReq) (*grpc
 		LoginCount: 0,
 	}
 
-	enc, err := model.EncryptPassword(req.Password)
+	if err := s.handler.Signup(ctx, user, req.Password); err != nil {
+		return nil, err
+	}
+
+	return &grpc_author.UserRes{
+		Code:    grpc_author.UserRes_VALID,
+		Id:      uint32(user.Id),
+		LoginId: user.LoginId,
+		Email:   user.Email,
+		Name:    user.Name,
+	}, nil
+}
+
+func (s userServer) ChangePassword(ctx context.Context, req *grpc_author.ChangePasswordReq) (*grpc_author.UserRes, error) {
+	user, code, err := s.findUserByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.UserRes{Code: code}, err
+	}
+
+	if _, err := s.handler.Ctx.PasswordHasher.Compare(user.Password, req.Password); err != nil {
+		return &grpc_author.UserRes{Code: grpc_author.UserRes_INVALID_PASSWORD}, nil
+	}
+
+	if req.NewPassword != req.NewPasswordConfirmation {
+		return &grpc_author.UserRes{Code: grpc_author.UserRes_PASSWORD_NOT_MATCHED}, nil
+	}
+
+	if violations, err := s.handler.ValidatePassword(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	} else if len(violations) > 0 {
+		return newViolationRes(violations), nil
+	}
+
+	if err := s.handler.ChangePassword(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	return &grpc_author.UserRes{
+		Code:    grpc_author.UserRes_VALID,
+		Id:      uint32(user.Id),
+		LoginId: user.LoginId,
+		Email:   user.Email,
+		Name:    user.Name,
+	}, nil
+}
+
+func (s userServer) GetMe(ctx context.Context, req *grpc_author.UserJwtReq) (*grpc_author.UserRes, error) {
+	user, code, err := s.findUserByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.UserRes{Code: code}, err
+	}
+
+	return user.GetGrpcRes()
+}
+
+func (s userServer) UpdateProfile(ctx context.Context, req *grpc_author.UpdateProfileReq) (*grpc_author.UserRes, error) {
+	user, code, err := s.findUserByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.UserRes{Code: code}, err
+	}
+
+	if err := s.handler.UpdateProfile(ctx, user, req.Name, req.Email); err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusConflict {
+			return &grpc_author.UserRes{Code: grpc_author.UserRes_DUPLICATE_EMAIL}, nil
+		}
+		return nil, err
+	}
+
+	return user.GetGrpcRes()
+}
+
+func (s userServer) VerifyEmail(ctx context.Context, req *grpc_author.VerifyEmailReq) (*grpc_author.UserRes, error) {
+	user, err := s.handler.VerifyEmail(ctx, req.Token)
 	if err != nil {
+		switch code, _ := errors.Decompose(err); code {
+		case http.StatusNotFound, http.StatusUnauthorized:
+			return &grpc_author.UserRes{Code: grpc_author.UserRes_INVALID_TOKEN}, nil
+		case http.StatusConflict:
+			return &grpc_author.UserRes{Code: grpc_author.UserRes_DUPLICATE_EMAIL}, nil
+		}
 		return nil, err
 	}
-	user.Password = enc
 
-	if _, err := s.handler.Ctx.Orm.Insert(user); err != nil {
+	return user.GetGrpcRes()
+}
+
+func (s userServer) DeleteAccount(ctx context.Context, req *grpc_author.DeleteAccountReq) (*grpc_author.UserRes, error) {
+	user, code, err := s.findUserByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.UserRes{Code: code}, err
+	}
+
+	if _, err := s.handler.Ctx.PasswordHasher.Compare(user.Password, req.Password); err != nil {
+		return &grpc_author.UserRes{Code: grpc_author.UserRes_INVALID_PASSWORD}, nil
+	}
+
+	if err := s.handler.DeleteAccount(ctx, user); err != nil {
 		return nil, err
 	}
 
+	return &grpc_author.UserRes{
+		Code:    grpc_author.UserRes_VALID,
+		Id:      uint32(user.Id),
+		LoginId: user.LoginId,
+	}, nil
+}
+
+func (s userServer) RequestPasswordReset(ctx context.Context, req *grpc_author.PasswordResetReq) (*grpc_author.UserRes, error) {
+	if err := s.handler.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, err
+	}
+
+	return &grpc_author.UserRes{Code: grpc_author.UserRes_VALID}, nil
+}
+
+func (s userServer) ConfirmPasswordReset(ctx context.Context, req *grpc_author.ConfirmPasswordResetReq) (*grpc_author.UserRes, error) {
+	if req.NewPassword != req.NewPasswordConfirmation {
+		return &grpc_author.UserRes{Code: grpc_author.UserRes_PASSWORD_NOT_MATCHED}, nil
+	}
+
+	user, violations, err := s.handler.ConfirmPasswordReset(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound || code == http.StatusUnauthorized {
+			return &grpc_author.UserRes{Code: grpc_author.UserRes_INVALID_TOKEN}, nil
+		}
+		return nil, err
+	} else if len(violations) > 0 {
+		return newViolationRes(violations), nil
+	}
+
 	return &grpc_author.UserRes{
 		Code:    grpc_author.UserRes_VALID,
 		Id:      uint32(user.Id),
@@ -64,3 +193,93 @@ func (s userServer) Signup(ctx context.Context, req *grpc_author.UserReq) (*grpc
 		Name:    user.Name,
 	}, nil
 }
+
+func (s userServer) ListUsers(ctx context.Context, req *grpc_author.ListUsersReq) (*grpc_author.ListUsersRes, error) {
+	_, code, err := s.findAdminByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.ListUsersRes{Code: code}, err
+	}
+
+	filter := model.UserFilter{
+		LoginId:        req.LoginId,
+		Email:          req.Email,
+		Name:           req.Name,
+		IncludeDeleted: req.IncludeDeleted,
+		Page:           int(req.Page),
+		PerPage:        int(req.PerPage),
+	}
+	users, total, err := model.FindUsers(ctx, s.handler.Ctx.Orm, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &grpc_author.ListUsersRes{
+		Code:    grpc_author.UserRes_VALID,
+		Total:   uint32(total),
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	}
+	for _, user := range users {
+		userRes, err := user.GetGrpcRes()
+		if err != nil {
+			return nil, err
+		}
+		res.Users = append(res.Users, userRes)
+	}
+
+	return res, nil
+}
+
+func (s userServer) GetUser(ctx context.Context, req *grpc_author.GetUserReq) (*grpc_author.UserRes, error) {
+	_, code, err := s.findAdminByJwt(ctx, req.Jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return &grpc_author.UserRes{Code: code}, err
+	}
+
+	user, err := s.handler.FindUser(ctx, uint(req.Id))
+	if err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound {
+			return &grpc_author.UserRes{Code: grpc_author.UserRes_NOT_FOUND}, nil
+		}
+		return nil, err
+	}
+
+	return user.GetGrpcRes()
+}
+
+// JWT에 해당하는 회원 조회, 인증 실패는 응답 코드로, 그 외 오류는 error로 반환
+func (s userServer) findUserByJwt(ctx context.Context, jwt string) (*model.User, grpc_author.UserRes_Code, error) {
+	user, err := model.FindUserByJwt(ctx, s.handler.Ctx.Orm, jwt)
+	if err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusUnauthorized || code == http.StatusNotFound {
+			return nil, grpc_author.UserRes_INVALID_TOKEN, nil
+		}
+		return nil, grpc_author.UserRes_INTERNAL_EXCEPTION, err
+	}
+
+	return user, grpc_author.UserRes_VALID, nil
+}
+
+func (s userServer) findAdminByJwt(ctx context.Context, jwt string) (*model.User, grpc_author.UserRes_Code, error) {
+	user, code, err := s.findUserByJwt(ctx, jwt)
+	if err != nil || code != grpc_author.UserRes_VALID {
+		return nil, code, err
+	}
+
+	if isAdmin, err := s.handler.IsAdmin(ctx, user); err != nil {
+		return nil, grpc_author.UserRes_INTERNAL_EXCEPTION, err
+	} else if !isAdmin {
+		return nil, grpc_author.UserRes_PERMISSION_DENIED, nil
+	}
+
+	return user, grpc_author.UserRes_VALID, nil
+}
+
+func newViolationRes(violations []policy.Violation) *grpc_author.UserRes {
+	res := &grpc_author.UserRes{Code: grpc_author.UserRes_PASSWORD_POLICY_VIOLATION}
+	for _, violation := range violations {
+		res.Violations = append(res.Violations, violation.GetGrpcViolation())
+	}
+
+	return res
+}
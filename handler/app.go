@@ -1,11 +1,18 @@
 package handler
 
 import (
+	"context"
+	"net/http"
 	"time"
 
 	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/logging"
 	"github.com/kekim-go/Author/model"
 	"github.com/thoas/go-funk"
+	"xorm.io/xorm"
 )
 
 type AppHandler struct {
@@ -16,51 +23,149 @@ func NewAppHandler(ctx *ctx.Context) *AppHandler {
 	return &AppHandler{Ctx: ctx}
 }
 
-func (h *AppHandler) Create(app *model.App) error {
-	session := h.Ctx.Orm.NewSession()
-	session.Begin()
+func (h *AppHandler) Find(ctx context.Context, appId uint) (*model.App, error) {
+	app := &model.App{Id: appId}
+	if err := app.FindApp(ctx, h.Ctx.Orm); err != nil {
+		return nil, err
+	}
 
-	if _, err := h.Ctx.Orm.Insert(app); err != nil {
-		session.Rollback()
-		return err
+	return app, nil
+}
+
+// FindDetail App과 Operation, Traffic 및 사용 현황 조회
+func (h *AppHandler) FindDetail(ctx context.Context, appId uint, includeDeleted bool) (*model.App, model.AppUsage, error) {
+	app := &model.App{Id: appId}
+
+	var err error
+	if includeDeleted {
+		err = app.FindAppWithDeleted(ctx, h.Ctx.Orm)
+	} else {
+		err = app.FindApp(ctx, h.Ctx.Orm)
+	}
+	if err != nil {
+		return nil, model.AppUsage{}, err
 	}
 
-	if _, err := h.Ctx.Orm.Insert(app.Traffics); err != nil {
-		session.Rollback()
+	if app.Operations, err = model.FindOperationsByApp(ctx, h.Ctx.Orm, app.Id); err != nil {
+		return nil, model.AppUsage{}, err
+	}
+	if app.Traffics, err = model.FindTrafficsByApp(ctx, h.Ctx.Orm, app.Id); err != nil {
+		return nil, model.AppUsage{}, err
+	}
+
+	usages, err := model.FindAppUsages(ctx, h.Ctx.Orm, []uint{app.Id})
+	if err != nil {
+		return nil, model.AppUsage{}, err
+	}
+
+	return app, usages[app.Id], nil
+}
+
+// List 조건에 맞는 App 목록과 App별 사용 현황, 전체 건수 조회
+func (h *AppHandler) List(ctx context.Context, filter model.AppFilter) ([]model.App, map[uint]model.AppUsage, int64, error) {
+	apps, total, err := model.FindApps(ctx, h.Ctx.Orm, filter)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var appIds []uint
+	for _, app := range apps {
+		appIds = append(appIds, app.Id)
+	}
+	usages, err := model.FindAppUsages(ctx, h.Ctx.Orm, appIds)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return apps, usages, total, nil
+}
+
+// Create 등록시 상태는 draft 또는 active만 허용
+func (h *AppHandler) Create(ctx context.Context, app *model.App) error {
+	if app.Status != constant.AppStatusDraft && app.Status != constant.AppStatusActive {
+		return errors.NewWithCode(http.StatusBadRequest, "invalid app status")
+	}
+
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
 		return err
 	}
 
-	if _, err := h.Ctx.Orm.Insert(app.Operations); err != nil {
+	if _, err := session.Insert(app); err != nil {
 		session.Rollback()
 		return err
 	}
 
-	session.Commit()
+	if len(app.Traffics) > 0 {
+		if _, err := session.Insert(app.Traffics); err != nil {
+			session.Rollback()
+			return err
+		}
+	}
 
-	return nil
+	if len(app.Operations) > 0 {
+		if _, err := session.Insert(app.Operations); err != nil {
+			session.Rollback()
+			return err
+		}
+	}
+
+	// 같은 namespace, endpoint로 남아 있던 캐시 삭제
+	outbox := &database.CacheOutbox{}
+	outbox.Add(app.KeyName())
+	for _, operation := range app.Operations {
+		outbox.Add(operation.KeyName())
+	}
+
+	return h.commit(ctx, session, outbox)
 }
 
-func (h *AppHandler) Update(app *model.App) error {
-	session := h.Ctx.Orm.NewSession()
-	session.Begin()
+func (h *AppHandler) Update(ctx context.Context, app *model.App) error {
+	logger := logging.FromContext(ctx, h.Ctx.Logger)
+
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
 
-	if _, err := h.Ctx.Orm.ID(app.Id).Update(app); err != nil {
+	if err := session.Begin(); err != nil {
 		return err
 	}
+	outbox := &database.CacheOutbox{}
 
-	originOperations, err := model.FindOperationsByApp(h.Ctx.Orm, app.Id)
+	origin := &model.App{Id: app.Id}
+	if err := origin.FindApp(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	// 조회 후 다른 수정이 없었는지 확인할 수 없으므로 version은 필수
+	if app.Version == 0 {
+		session.Rollback()
+		return errors.NewWithCode(http.StatusPreconditionRequired, "version is required")
+	}
+	if err := app.Update(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+	// 캐시된 App 정보 삭제, namespace가 바뀐 경우 이전 키도 함께 삭제
+	outbox.Add(origin.KeyName(), app.KeyName())
+
+	originOperations, err := model.FindOperationsByApp(ctx, session, app.Id)
 	if err != nil {
+		session.Rollback()
 		return err
 	}
 	var originIds []uint
 	for _, operation := range originOperations {
 		originIds = append(originIds, operation.Id)
-		operation.DelRedis(h.Ctx.RedisDB) // 수정이 발생할 수 있는 operation에 대한 redis 삭제 처리
+		outbox.Add(operation.KeyName()) // 수정이 발생할 수 있는 operation에 대한 redis 삭제 처리
 	}
 
 	var newIds []uint
 	for _, operation := range app.Operations {
 		newIds = append(newIds, operation.Id)
+		outbox.Add(operation.KeyName())
 	}
 
 	//기존 데이터와 공통되는(Update 대상) ID 추출
@@ -68,10 +173,14 @@ func (h *AppHandler) Update(app *model.App) error {
 	for _, id := range updatedIds.([]uint) {
 		idx := funk.IndexOf(newIds, id)
 		operation := app.Operations[idx]
+		if operation.Version == 0 {
+			session.Rollback()
+			return errors.NewWithCode(http.StatusPreconditionRequired, "operation version is required")
+		}
 
-		err := operation.Update(h.Ctx.Orm)
+		err := operation.Update(ctx, session)
 		if err != nil {
-			h.Ctx.Logger.Info(err)
+			logger.Info(err)
 			session.Rollback()
 			return err
 		}
@@ -82,9 +191,9 @@ func (h *AppHandler) Update(app *model.App) error {
 	for _, id := range deleteIds.([]uint) {
 		idx := funk.IndexOf(originIds, id)
 		delOperation := originOperations[idx]
-		err := delOperation.Delete(h.Ctx.Orm)
+		err := delOperation.Delete(ctx, session)
 		if err != nil {
-			h.Ctx.Logger.Info(err)
+			logger.Info(err)
 			session.Rollback()
 			return err
 		}
@@ -96,83 +205,313 @@ func (h *AppHandler) Update(app *model.App) error {
 			idx := funk.IndexOf(newIds, id)
 			operations = append(operations, app.Operations[idx])
 		}
-		if _, err := h.Ctx.Orm.Insert(operations); err != nil {
+		if _, err := session.Insert(operations); err != nil {
 			session.Rollback()
 			return err
 		}
 	}
 
-	traffics, err := model.FindTrafficsByApp(h.Ctx.Orm, app.Id)
-	h.Ctx.Logger.Debug(traffics)
+	traffics, err := model.FindTrafficsByApp(ctx, session, app.Id)
+	if err != nil {
+		session.Rollback()
+		return err
+	}
+	logger.Debug(traffics)
 	for _, traffic := range traffics {
-		traffic.DelRedis(h.Ctx.RedisDB)
-		err := traffic.Delete(h.Ctx.Orm)
+		outbox.Add(traffic.KeyName())
+		err := traffic.Delete(ctx, session)
 		if err != nil {
 			session.Rollback()
 			return err
 		}
 	}
 
-	if _, err := h.Ctx.Orm.Insert(app.Traffics); err != nil {
+	if len(app.Traffics) > 0 {
+		if _, err := session.Insert(app.Traffics); err != nil {
+			session.Rollback()
+			return err
+		}
+	}
+
+	return h.commit(ctx, session, outbox)
+}
+
+// ChangeStatus App 상태 변경, deprecated가 아니면 종료 예정 시각은 삭제
+func (h *AppHandler) ChangeStatus(ctx context.Context, app *model.App) error {
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	origin := &model.App{Id: app.Id}
+	if err := origin.FindApp(ctx, session); err != nil {
 		session.Rollback()
 		return err
 	}
 
-	session.Commit()
+	if !origin.CanChangeStatus(app.Status) {
+		session.Rollback()
+		return errors.NewWithCode(http.StatusBadRequest, "invalid status transition: "+origin.CurrentStatus()+" -> "+app.Status)
+	}
+	if app.Status != constant.AppStatusDeprecated {
+		app.SunsetAt = nil
+	}
 
-	return nil
+	if app.Version == 0 {
+		session.Rollback()
+		return errors.NewWithCode(http.StatusPreconditionRequired, "version is required")
+	}
+	if err := app.UpdateStatus(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	outbox := &database.CacheOutbox{}
+	outbox.Add(origin.KeyName())
+
+	return h.commit(ctx, session, outbox)
 }
 
-func (h *AppHandler) Destroy(appId uint) error {
-	session := h.Ctx.Orm.NewSession()
-	session.Begin()
+func (h *AppHandler) Destroy(ctx context.Context, appId uint) error {
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+	outbox := &database.CacheOutbox{}
 
-	// 1. Operation 삭제 처리
+	app := &model.App{Id: appId}
+	if err := app.FindApp(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	// 1. Operation 삭제 처리, 복구 대상 구분을 위해 is_del 표시
 	var operations []model.Operation
-	if err := h.Ctx.Orm.Where("app_id = ?", appId).Find(&operations); err != nil {
+	if err := session.Where("app_id = ?", appId).Find(&operations); err != nil {
 		session.Rollback()
 		return err
 	}
-	// 1-1. DB 삭제 처리
-	operationSql := "UPDATE operation SET deleted_at = ?, is_del = 1 WHERE app_id = ? AND deleted_at IS NULL"
-	if _, err := h.Ctx.Orm.Exec(operationSql, time.Now(), appId); err != nil {
+	operationSql := "UPDATE operation SET deleted_at = ?, is_del = ? WHERE app_id = ? AND deleted_at IS NULL"
+	if _, err := session.Exec(operationSql, time.Now(), true, appId); err != nil {
 		session.Rollback()
 		return err
 	}
-	// 1-2. Redis 삭제 처리
 	for _, operation := range operations {
-		operation.DelRedis(h.Ctx.RedisDB)
+		outbox.Add(operation.KeyName())
 	}
 
 	// 2. Traffic 삭제 처리
 	var traffics []model.Traffic
-	if err := h.Ctx.Orm.Where("app_id = ?", appId).Find(&traffics); err != nil {
+	if err := session.Where("app_id = ?", appId).Find(&traffics); err != nil {
 		session.Rollback()
 		return err
 	}
-	// 2-1. DB 삭제 처리
 	trafficSql := "DELETE FROM traffic WHERE app_id = ?"
-	if _, err := h.Ctx.Orm.Exec(trafficSql, appId); err != nil {
+	if _, err := session.Exec(trafficSql, appId); err != nil {
 		session.Rollback()
 		return err
 	}
-
-	// 2-2. Redis 삭제 처리
+	// 2-1. 복구를 위해 삭제한 Traffic 보관
+	var archives []model.TrafficArchive
 	for _, traffic := range traffics {
-		traffic.DelRedis(h.Ctx.RedisDB)
+		outbox.Add(traffic.KeyName())
+		archives = append(archives, model.NewTrafficArchive(traffic))
 	}
+	if len(archives) > 0 {
+		if _, err := session.Insert(archives); err != nil {
+			session.Rollback()
+			return err
+		}
+	}
+
+	// 3. App 삭제 처리
+	if err := app.Delete(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+	outbox.Add(app.KeyName())
+
+	return h.commit(ctx, session, outbox)
+}
+
+// Restore 삭제된 App과 함께 삭제된 Operation을 복구하고 보관한 Traffic을 다시 등록
+func (h *AppHandler) Restore(ctx context.Context, appId uint) (*model.App, error) {
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+	outbox := &database.CacheOutbox{}
 
 	app := &model.App{Id: appId}
+	if err := app.FindAppWithDeleted(ctx, session); err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	if err := h.checkRestorable(ctx, session, app); err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	if err := app.Restore(ctx, session); err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	outbox.Add(app.KeyName())
+
+	if err := model.RestoreOperationsByApp(ctx, session, appId); err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	operations, err := model.FindOperationsByApp(ctx, session, appId)
+	if err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	for _, operation := range operations {
+		outbox.Add(operation.KeyName())
+	}
+
+	archives, err := model.FindTrafficArchivesByApp(ctx, session, appId)
+	if err != nil {
+		session.Rollback()
+		return nil, err
+	}
+	var traffics []model.Traffic
+	for _, archive := range archives {
+		traffic := archive.Traffic()
+		outbox.Add(traffic.KeyName())
+		traffics = append(traffics, traffic)
+	}
+	if len(traffics) > 0 {
+		if _, err := session.Insert(traffics); err != nil {
+			session.Rollback()
+			return nil, err
+		}
+		if err := model.DeleteTrafficArchivesByApps(ctx, session, appId); err != nil {
+			session.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := h.commit(ctx, session, outbox); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// checkRestorable 복구 전에 충돌 확인, 복구하면 중복되는 데이터가 있으면 409 오류
+func (h *AppHandler) checkRestorable(ctx context.Context, session *xorm.Session, app *model.App) error {
+	if app.DeletedAt == nil {
+		return errors.NewWithCode(http.StatusConflict, "app is not deleted")
+	}
+
+	taken, err := app.IsNameSpaceTaken(ctx, session)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.NewWithCode(http.StatusConflict, "name space is already in use: "+app.NameSpace)
+	}
+
+	// 보관한 Traffic을 다시 등록하므로 이미 Traffic이 있으면 중복
+	traffics, err := model.FindTrafficsByApp(ctx, session, app.Id)
+	if err != nil {
+		return err
+	}
+	if len(traffics) > 0 {
+		return errors.NewWithCode(http.StatusConflict, "app traffics already exist")
+	}
+
+	return nil
+}
+
+// Purge before 이전에 삭제된 App과 관련 데이터(Operation, 보관한 Traffic, 키 허가)를 영구 삭제하고 삭제한 App 수 반환
+// 삭제된 App은 캐시에서 이미 지워졌으므로 캐시는 다루지 않음
+// 여러 인스턴스의 스케줄이 겹치지 않도록 lock을 얻은 경우에만 실행하며, 다른 인스턴스가 실행 중이면 0 반환
+func (h *AppHandler) Purge(ctx context.Context, before time.Time) (int, error) {
+	logger := logging.FromContext(ctx, h.Ctx.Logger)
+
+	unlock, acquired, err := database.AdvisoryLock(ctx, h.Ctx.Orm, constant.PurgeLockName, constant.PurgeLockId, 0)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		logger.Info("purge is running on another instance")
+		return 0, nil
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			logger.Warn(err)
+		}
+	}()
+
+	purged := 0
+	for {
+		appIds, err := model.FindAppIdsDeletedBefore(ctx, h.Ctx.Orm, before, constant.PurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		if err := h.purge(ctx, before, appIds); err != nil {
+			return purged, err
+		}
+		purged += len(appIds)
+
+		if len(appIds) < constant.PurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (h *AppHandler) purge(ctx context.Context, before time.Time, appIds []uint) error {
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
 
-	if err := app.FindApp(h.Ctx.Orm); err != nil {
+	// App 수정 중 삭제된 Operation도 함께 정리
+	if err := model.PurgeOperations(ctx, session, before, appIds...); err != nil {
 		session.Rollback()
 		return err
 	}
 
-	app.DelRedis(h.Ctx.RedisDB)
-	app.Delete(h.Ctx.Orm)
+	if len(appIds) > 0 {
+		if err := model.DeleteTrafficArchivesByApps(ctx, session, appIds...); err != nil {
+			session.Rollback()
+			return err
+		}
+		if err := model.DeleteAppTokensByApps(ctx, session, appIds...); err != nil {
+			session.Rollback()
+			return err
+		}
+		if err := model.PurgeApps(ctx, session, appIds...); err != nil {
+			session.Rollback()
+			return err
+		}
+	}
 
-	session.Commit()
+	return session.Commit()
+}
+
+// commit 트랜잭션 커밋 후 모아 둔 캐시 키 삭제
+// 커밋 전에 캐시를 지우면 그 사이 조회가 이전 DB 값으로 캐시를 다시 채울 수 있으므로 커밋 후에만 삭제
+// 캐시 삭제에 실패해도 DB 변경은 이미 반영되었으므로 오류로 반환하지 않고 기록만 남김
+func (h *AppHandler) commit(ctx context.Context, session *xorm.Session, outbox *database.CacheOutbox) error {
+	if err := session.Commit(); err != nil {
+		return err
+	}
+
+	if err := outbox.Flush(ctx, h.Ctx.RedisDB); err != nil {
+		logging.FromContext(ctx, h.Ctx.Logger).WithError(err).Warn("cache invalidation failed")
+	}
 
 	return nil
 }
@@ -0,0 +1,391 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/model"
+)
+
+func newTestApp(id uint, nameSpace string, operationIds ...uint) *model.App {
+	app := &model.App{Id: id, NameSpace: nameSpace, Status: constant.AppStatusActive}
+	for _, operationId := range operationIds {
+		app.Operations = append(app.Operations, model.Operation{Id: operationId, AppId: id, EndPoint: "/op"})
+	}
+	app.Traffics = []model.Traffic{{AppId: id, Unit: "hour", Val: 100, Seq: 1}, {AppId: id, Unit: "day", Val: 1000, Seq: 2}}
+
+	return app
+}
+
+func createTestApp(t *testing.T, c *ctx.Context, app *model.App) *model.App {
+	t.Helper()
+
+	if err := NewAppHandler(c).Create(context.Background(), app); err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func countRows(t *testing.T, c *ctx.Context, bean interface{}, query string, args ...interface{}) int64 {
+	t.Helper()
+
+	count, err := c.Orm.Unscoped().Where(query, args...).Count(bean)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func TestAppCreate(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+
+	createTestApp(t, c, newTestApp(1, "svc", 10, 11))
+
+	app, usage, err := h.FindDetail(background, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.NameSpace != "svc" || app.Version != 1 || len(app.Operations) != 2 || len(app.Traffics) != 2 || usage.OperationCount != 2 {
+		t.Errorf("app = %+v, usage = %+v", app, usage)
+	}
+
+	invalid := newTestApp(2, "other")
+	invalid.Status = constant.AppStatusSuspended
+	if err := h.Create(background, invalid); errorCode(err) != 400 {
+		t.Errorf("create suspended app err = %v, want 400", err)
+	}
+}
+
+// 등록 중 오류가 나면 먼저 저장한 App, Traffic도 저장되지 않아야 함
+func TestAppCreateRollback(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	createTestApp(t, c, newTestApp(1, "svc", 10))
+
+	// 이미 있는 operation id로 등록하면 operation 저장에서 실패
+	if err := h.Create(context.Background(), newTestApp(2, "other", 10)); err == nil {
+		t.Fatal("duplicate operation id must fail")
+	}
+
+	if count := countRows(t, c, &model.App{}, "id = ?", 2); count != 0 {
+		t.Errorf("app rows = %d, want 0", count)
+	}
+	if count := countRows(t, c, &model.Traffic{}, "app_id = ?", 2); count != 0 {
+		t.Errorf("traffic rows = %d, want 0", count)
+	}
+}
+
+func TestAppUpdate(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	created := createTestApp(t, c, newTestApp(1, "svc", 10, 11))
+
+	// 10 수정, 11 삭제, 12 추가, Traffic 교체
+	update := newTestApp(1, "svc2", 10, 12)
+	update.Operations[0].EndPoint = "/changed"
+	update.Operations[0].Version = created.Operations[0].Version
+	update.Traffics = []model.Traffic{{AppId: 1, Unit: "month", Val: 5000, Seq: 1}}
+	update.Version = created.Version
+	if err := h.Update(background, update); err != nil {
+		t.Fatal(err)
+	}
+
+	app, _, err := h.FindDetail(background, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.NameSpace != "svc2" || app.Version != created.Version+1 {
+		t.Errorf("app = %+v", app)
+	}
+	endPoints := map[uint]string{}
+	for _, operation := range app.Operations {
+		endPoints[operation.Id] = operation.EndPoint
+	}
+	if len(endPoints) != 2 || endPoints[10] != "/changed" || endPoints[12] != "/op" {
+		t.Errorf("operations = %v", endPoints)
+	}
+	if len(app.Traffics) != 1 || app.Traffics[0].Unit != "month" {
+		t.Errorf("traffics = %+v", app.Traffics)
+	}
+}
+
+// version을 보내지 않거나 조회 후 다른 수정이 있었으면 수정하지 않아야 함
+func TestAppUpdateVersion(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	created := createTestApp(t, c, newTestApp(1, "svc", 10))
+
+	update := newTestApp(1, "renamed", 10)
+	update.Operations[0].Version = created.Operations[0].Version
+	if err := h.Update(background, update); errorCode(err) != 428 {
+		t.Errorf("update without version err = %v, want 428", err)
+	}
+
+	update = newTestApp(1, "renamed", 10)
+	update.Version = created.Version
+	if err := h.Update(background, update); errorCode(err) != 428 {
+		t.Errorf("update without operation version err = %v, want 428", err)
+	}
+
+	update = newTestApp(1, "renamed", 10)
+	update.Version = created.Version + 1
+	update.Operations[0].Version = created.Operations[0].Version
+	if err := h.Update(background, update); errorCode(err) != 409 {
+		t.Errorf("update with stale version err = %v, want 409", err)
+	}
+
+	update = newTestApp(1, "renamed", 10)
+	update.Version = created.Version
+	update.Operations[0].Version = created.Operations[0].Version + 1
+	if err := h.Update(background, update); errorCode(err) != 409 {
+		t.Errorf("update with stale operation version err = %v, want 409", err)
+	}
+
+	app, _, err := h.FindDetail(background, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.NameSpace != "svc" || app.Version != created.Version {
+		t.Errorf("app after rejected updates = %+v", app)
+	}
+}
+
+// 수정 중 오류가 나면 App, Operation, Traffic 변경이 모두 되돌려져야 함
+func TestAppUpdateRollback(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	created := createTestApp(t, c, newTestApp(1, "svc", 10))
+	createTestApp(t, c, newTestApp(2, "other", 20))
+
+	// 다른 App의 operation id를 추가하면 operation 저장에서 실패
+	update := newTestApp(1, "renamed", 20)
+	update.Traffics = nil
+	update.Version = created.Version
+	if err := h.Update(background, update); err == nil {
+		t.Fatal("duplicate operation id must fail")
+	}
+
+	app, _, err := h.FindDetail(background, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.NameSpace != "svc" || app.Version != created.Version || len(app.Operations) != 1 || app.Operations[0].Id != 10 || len(app.Traffics) != 2 {
+		t.Errorf("app after rollback = %+v, operations = %+v, traffics = %+v", app, app.Operations, app.Traffics)
+	}
+}
+
+func TestAppChangeStatus(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	created := createTestApp(t, c, newTestApp(1, "svc", 10))
+
+	find := func() *model.App {
+		t.Helper()
+		app, _, err := h.FindDetail(background, 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return app
+	}
+	sunsetAt := time.Now().Add(time.Hour)
+
+	if err := h.ChangeStatus(background, &model.App{Id: 1, Status: constant.AppStatusSuspended}); errorCode(err) != 428 {
+		t.Errorf("change status without version err = %v, want 428", err)
+	}
+	if err := h.ChangeStatus(background, &model.App{Id: 1, Status: constant.AppStatusDraft, Version: created.Version}); errorCode(err) != 400 {
+		t.Errorf("active -> draft err = %v, want 400", err)
+	}
+	if err := h.ChangeStatus(background, &model.App{Id: 2, Status: constant.AppStatusSuspended, Version: 1}); errorCode(err) != 404 {
+		t.Errorf("change status of unknown app err = %v, want 404", err)
+	}
+
+	if err := h.ChangeStatus(background, &model.App{Id: 1, Status: constant.AppStatusDeprecated, SunsetAt: &sunsetAt, Version: created.Version}); err != nil {
+		t.Fatal(err)
+	}
+	deprecated := find()
+	if deprecated.Status != constant.AppStatusDeprecated || deprecated.SunsetAt == nil || deprecated.Version != created.Version+1 {
+		t.Errorf("deprecated app = %+v", deprecated)
+	}
+
+	// 조회 후 다른 변경이 있었으면 409
+	if err := h.ChangeStatus(background, &model.App{Id: 1, Status: constant.AppStatusSuspended, Version: created.Version}); errorCode(err) != 409 {
+		t.Errorf("change status with stale version err = %v, want 409", err)
+	}
+
+	// deprecated가 아니면 요청에 종료 예정 시각이 있어도 삭제
+	if err := h.ChangeStatus(background, &model.App{Id: 1, Status: constant.AppStatusActive, SunsetAt: &sunsetAt, Version: deprecated.Version}); err != nil {
+		t.Fatal(err)
+	}
+	active := find()
+	if active.Status != constant.AppStatusActive || active.SunsetAt != nil || active.Version != deprecated.Version+1 {
+		t.Errorf("active app = %+v", active)
+	}
+}
+
+func TestAppDestroy(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	createTestApp(t, c, newTestApp(1, "svc", 10, 11))
+
+	if err := h.Destroy(background, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.Find(background, 1); errorCode(err) != 404 {
+		t.Errorf("find destroyed app err = %v, want 404", err)
+	}
+	app, _, err := h.FindDetail(background, 1, true)
+	if err != nil || app.DeletedAt == nil || !app.IsDel {
+		t.Errorf("destroyed app = %+v, %v", app, err)
+	}
+
+	if count := countRows(t, c, &model.Operation{}, "app_id = ? AND deleted_at IS NOT NULL AND is_del = ?", 1, true); count != 2 {
+		t.Errorf("deleted operations = %d, want 2", count)
+	}
+	if count := countRows(t, c, &model.Traffic{}, "app_id = ?", 1); count != 0 {
+		t.Errorf("traffic rows = %d, want 0", count)
+	}
+	if count := countRows(t, c, &model.TrafficArchive{}, "app_id = ?", 1); count != 2 {
+		t.Errorf("archived traffics = %d, want 2", count)
+	}
+
+	if err := h.Destroy(background, 1); errorCode(err) != 404 {
+		t.Errorf("destroy twice err = %v, want 404", err)
+	}
+}
+
+func TestAppRestore(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	created := createTestApp(t, c, newTestApp(1, "svc", 10, 11))
+
+	if _, err := h.Restore(background, 1); errorCode(err) != 409 {
+		t.Errorf("restore active app err = %v, want 409", err)
+	}
+	if err := h.Destroy(background, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := h.Restore(background, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Version != created.Version+1 {
+		t.Errorf("restored version = %d, want %d", restored.Version, created.Version+1)
+	}
+
+	app, usage, err := h.FindDetail(background, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.DeletedAt != nil || app.IsDel || usage.OperationCount != 2 || len(app.Traffics) != 2 {
+		t.Errorf("restored app = %+v, usage = %+v, traffics = %+v", app, usage, app.Traffics)
+	}
+	if count := countRows(t, c, &model.TrafficArchive{}, "app_id = ?", 1); count != 0 {
+		t.Errorf("archived traffics = %d, want 0", count)
+	}
+
+	if _, err := h.Restore(background, 2); errorCode(err) != 404 {
+		t.Errorf("restore unknown app err = %v, want 404", err)
+	}
+}
+
+// 복구하면 Traffic이 중복되는 경우 아무것도 복구하지 않고 409
+func TestAppRestoreTrafficConflict(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	createTestApp(t, c, newTestApp(1, "svc", 10))
+
+	if err := h.Destroy(background, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Insert(&model.Traffic{AppId: 1, Unit: "hour", Val: 1, Seq: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.Restore(background, 1); errorCode(err) != 409 {
+		t.Fatalf("restore with existing traffics err = %v, want 409", err)
+	}
+
+	if _, err := h.Find(background, 1); errorCode(err) != 404 {
+		t.Errorf("find app after rejected restore err = %v, want 404", err)
+	}
+	if count := countRows(t, c, &model.Operation{}, "app_id = ? AND deleted_at IS NULL", 1); count != 0 {
+		t.Errorf("restored operations = %d, want 0", count)
+	}
+	if count := countRows(t, c, &model.TrafficArchive{}, "app_id = ?", 1); count != 2 {
+		t.Errorf("archived traffics = %d, want 2", count)
+	}
+}
+
+// 보관 기간이 지난 App만 Operation, 보관한 Traffic, 키 허가와 함께 영구 삭제
+func TestAppPurge(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAppHandler(c)
+	background := context.Background()
+	createTestApp(t, c, newTestApp(1, "old", 10))
+	createTestApp(t, c, newTestApp(2, "recent", 20))
+	createTestApp(t, c, newTestApp(3, "active", 30))
+
+	for _, appId := range []uint{1, 2} {
+		if _, err := c.Orm.Insert(&model.AppToken{AppId: appId, TokenId: 1}); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Destroy(background, appId); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := time.Now().Add(-time.Hour)
+	deletedAt := before.Add(-time.Hour)
+	if _, err := c.Orm.Exec("UPDATE app SET deleted_at = ? WHERE id = ?", deletedAt, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Exec("UPDATE operation SET deleted_at = ? WHERE app_id = ?", deletedAt, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := h.Purge(background, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 {
+		t.Errorf("purged = %d, want 1", purged)
+	}
+
+	for _, test := range []struct {
+		bean  interface{}
+		query string
+		want  map[uint]int64
+	}{
+		{&model.App{}, "id = ?", map[uint]int64{1: 0, 2: 1, 3: 1}},
+		{&model.Operation{}, "app_id = ?", map[uint]int64{1: 0, 2: 1, 3: 1}},
+		{&model.TrafficArchive{}, "app_id = ?", map[uint]int64{1: 0, 2: 2, 3: 0}},
+		{&model.AppToken{}, "app_id = ?", map[uint]int64{1: 0, 2: 1, 3: 0}},
+	} {
+		for appId, want := range test.want {
+			if count := countRows(t, c, test.bean, test.query, appId); count != want {
+				t.Errorf("%T rows of app %d = %d, want %d", test.bean, appId, count, want)
+			}
+		}
+	}
+
+	// 영구 삭제된 App은 복구할 수 없음
+	if _, err := h.Restore(background, 1); errorCode(err) != 404 {
+		t.Errorf("restore purged app err = %v, want 404", err)
+	}
+}
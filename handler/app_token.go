@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/kekim-go/Author/app/ctx"
 	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/metrics"
 	"github.com/kekim-go/Author/model"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
-	"github.com/sirupsen/logrus"
 )
 
 type AppTokenHandler struct {
@@ -21,144 +25,249 @@ func NewAppTokenHandler(ctx *ctx.Context) *AppTokenHandler {
 	}
 }
 
-func (h *AppTokenHandler) CheckAppToken(token *model.Token, operation *model.Operation) grpc_author.ApiAuthRes_Code {
-	h.Ctx.Logger.Debug(fmt.Sprintf("token: %+v, operation: %+v", token, operation))
+// TrafficUsage : 단위별 트래픽 허용치 및 이번 호출을 포함한 사용량
+type TrafficUsage struct {
+	Unit  string
+	Limit uint
+	Used  uint
+}
+
+func (h *AppTokenHandler) CheckAppToken(ctx context.Context, token *model.Token, operation *model.Operation) grpc_author.ApiAuthRes_Code {
+	code, _ := h.CheckAppTokenUsage(ctx, token, operation)
+	return code
+}
+
+// CheckAppTokenUsage CheckAppToken과 같으며, rate-limit 헤더 생성을 위해 단위별 사용량도 함께 반환
+func (h *AppTokenHandler) CheckAppTokenUsage(ctx context.Context, token *model.Token, operation *model.Operation) (grpc_author.ApiAuthRes_Code, []TrafficUsage) {
+	code, usages := h.checkAppToken(ctx, token, operation)
+
+	// 등록되지 않은 namespace로 인한 label 증가를 막기 위해 확인된 App만 namespace 기록
+	nameSpace := ""
+	if operation.App.Id > 0 {
+		nameSpace = operation.App.NameSpace
+	}
+	metrics.AppTokenChecks.WithLabelValues(nameSpace, code.String()).Inc()
+
+	return code, usages
+}
+
+func (h *AppTokenHandler) checkAppToken(ctx context.Context, token *model.Token, operation *model.Operation) (grpc_author.ApiAuthRes_Code, []TrafficUsage) {
+	logger := logging.FromContext(ctx, h.Ctx.Logger)
+	logger.Debug(fmt.Sprintf("operation: %+v", operation))
+
+	if !h.Ctx.RedisDB.Available() {
+		return h.checkAppTokenDegraded(ctx, token, operation)
+	}
 
 	// App 조회
-	appKey := operation.App.KeyName()
-	appId, err := h.Ctx.RedisDB.Get(appKey, "uint")
-	if err != nil && err == redis.Nil {
-		err = operation.App.FindApp(h.Ctx.Orm)
+	err := operation.App.GetRedis(ctx, h.Ctx.RedisDB)
+	metrics.CacheLookup(metrics.CacheApp, err == nil)
+	if database.IsMiss(err) {
+		err = operation.App.FindApp(ctx, h.Ctx.Orm)
 		if err != nil {
-			return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE
+			return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE, nil
 		}
-		h.Ctx.Logger.WithField("DB", fmt.Sprintf("%+v", operation.App)).Debug("Find App")
-		h.Ctx.RedisDB.Set(appKey, operation.App.Id)
+		logger.WithField("DB", fmt.Sprintf("%+v", operation.App)).Debug("Find App")
+		operation.App.SetRedis(ctx, h.Ctx.RedisDB)
+	} else if err != nil {
+		return h.checkAppTokenDegraded(ctx, token, operation)
 	} else {
-		h.Ctx.Logger.WithField("Redis", appId).Debug("Find App")
-		operation.App.Id = appId.(uint)
+		logger.WithField("Redis", operation.App.Id).Debug("Find App")
 	}
 	operation.AppId = operation.App.Id
+	if code := appStatusCode(&operation.App, time.Now()); code != grpc_author.ApiAuthRes_VALID {
+		return code, nil
+	}
 
 	// Operation 조회
-	opKey := operation.KeyName()
-	opId, err := h.Ctx.RedisDB.Get(opKey, "uint")
-	if err != nil && err == redis.Nil {
-		err = operation.FindOperation(h.Ctx.Orm)
+	err = operation.GetRedis(ctx, h.Ctx.RedisDB)
+	metrics.CacheLookup(metrics.CacheOperation, err == nil)
+	if database.IsMiss(err) {
+		err = operation.FindOperation(ctx, h.Ctx.Orm)
 		if err != nil {
-			return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE
+			return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE, nil
 		}
-		h.Ctx.Logger.WithField("DB", fmt.Sprintf("%+v", operation)).Debug("Find Operation")
-		operation.SetRedis(h.Ctx.RedisDB)
+		logger.WithField("DB", fmt.Sprintf("%+v", operation)).Debug("Find Operation")
+		operation.SetRedis(ctx, h.Ctx.RedisDB)
+	} else if err != nil {
+		return h.checkAppTokenDegraded(ctx, token, operation)
 	} else {
-		h.Ctx.Logger.WithField("Redis", opId).Debug("Find Operation")
-		operation.Id = opId.(uint)
+		logger.WithField("Redis", operation.Id).Debug("Find Operation")
 	}
 
 	// Token 조회
-	tokenKey := token.KeyName()
-	tokenId, err := h.Ctx.RedisDB.Get(tokenKey, "uint")
-	if err != nil && err == redis.Nil {
-		err = token.FindByToken(h.Ctx.Orm)
-		if err = token.FindByToken(h.Ctx.Orm); err != nil {
-			return grpc_author.ApiAuthRes_UNAUTHORIZED
+	err = token.GetRedis(ctx, h.Ctx.RedisDB)
+	metrics.CacheLookup(metrics.CacheToken, err == nil)
+	if database.IsMiss(err) {
+		if err = token.FindByToken(ctx, h.Ctx.Orm); err != nil {
+			return grpc_author.ApiAuthRes_UNAUTHORIZED, nil
 		}
-		h.Ctx.Logger.WithField("DB", fmt.Sprintf("%+v", token)).Debug("Find Token")
-		h.Ctx.RedisDB.Set(tokenKey, token.Id)
+		logger.WithField("DB", token.Id).Debug("Find Token")
+		token.SetRedis(ctx, h.Ctx.RedisDB)
+	} else if err != nil {
+		return h.checkAppTokenDegraded(ctx, token, operation)
 	} else {
-		h.Ctx.Logger.WithField("Redis", tokenId).Debug("Find Token")
-		token.Id = tokenId.(uint)
+		logger.WithField("Redis", token.Id).Debug("Find Token")
 	}
 
 	// App-Token 조회
 	appToken := model.AppToken{TokenId: token.Id, AppId: operation.AppId}
-	appTokenKey := appToken.KeyName()
-	appTokenId, err := h.Ctx.RedisDB.Get(appTokenKey, "uint")
-	if err != nil && err == redis.Nil {
-		err = appToken.FindByAppAndToken(h.Ctx.Orm)
+	err = appToken.GetRedis(ctx, h.Ctx.RedisDB)
+	metrics.CacheLookup(metrics.CacheAppToken, err == nil)
+	if database.IsMiss(err) {
+		err = appToken.FindByAppAndToken(ctx, h.Ctx.Orm)
 		if err != nil {
-			return grpc_author.ApiAuthRes_UNAUTHORIZED
+			return grpc_author.ApiAuthRes_UNAUTHORIZED, nil
 		}
-		h.Ctx.Logger.WithField("DB", fmt.Sprintf("%+v", appToken)).Debug("Find AppToken")
-		// App-Token 정보는 24시간 유지
-		h.Ctx.RedisDB.SetWithExpiration(appTokenKey, appToken.Id, 60*60*24)
+		logger.WithField("DB", fmt.Sprintf("%+v", appToken)).Debug("Find AppToken")
+		appToken.SetRedis(ctx, h.Ctx.RedisDB)
+	} else if err != nil {
+		return h.checkAppTokenDegraded(ctx, token, operation)
 	} else {
-		h.Ctx.Logger.WithField("Redis", tokenId).Debug("Find AppToken")
-		appToken.Id = appTokenId.(uint)
+		logger.WithField("Redis", appToken.Id).Debug("Find AppToken")
 	}
 
 	// App-Traffic 조회
-	var finded = false
-	var trafficMap = map[string]uint{}
+	var trafficKeys []string
 	for _, unit := range constant.GetTrafficUnits() {
 		t := model.Traffic{Unit: unit, AppId: operation.AppId}
-		key := t.KeyName()
-		trafficVal, err := h.Ctx.RedisDB.Get(key, "uint")
-		if err == nil {
-			finded = true
-			trafficMap[key] = trafficVal.(uint)
-		}
+		trafficKeys = append(trafficKeys, t.KeyName())
 	}
-	if !finded {
+	trafficMap, err := h.Ctx.RedisDB.GetUints(ctx, trafficKeys...)
+	if err != nil && !database.IsMiss(err) {
+		return h.checkAppTokenDegraded(ctx, token, operation)
+	}
+	metrics.CacheLookup(metrics.CacheTraffic, len(trafficMap) > 0)
+	if len(trafficMap) == 0 {
 		// Traffic 조회 및 Cache
-		traffics, err := model.FindTrafficsByApp(h.Ctx.Orm, operation.AppId)
+		traffics, err := model.FindTrafficsByApp(ctx, h.Ctx.Orm, operation.AppId)
 		if err != nil {
-			h.Ctx.Logger.WithField("DB", appToken.Id).Info("Count not found AppToken Traffic Info")
-			return grpc_author.ApiAuthRes_UNKNOWN
+			logger.WithField("DB", appToken.Id).Info("Count not found AppToken Traffic Info")
+			return grpc_author.ApiAuthRes_UNKNOWN, nil
 		}
 
+		trafficMap = map[string]uint{}
 		for _, traffic := range traffics {
 			key := traffic.KeyName()
-			h.Ctx.RedisDB.Set(key, traffic.Val)
+			h.Ctx.RedisDB.Set(ctx, key, traffic.Val)
 			trafficMap[key] = traffic.Val
 		}
 	}
 
-	// 사용자 트래픽 조회
-	var isValid = true
+	// 사용자 트래픽 확인 및 증가
+	var counts []model.TrafficCount
 	for _, unit := range constant.GetTrafficUnits() {
-		var tokenTraffic uint
 		t := model.Traffic{Unit: unit, AppId: operation.AppId}
-		appTrafficKey := t.KeyName()
-
-		if maxTraffic, ok := trafficMap[appTrafficKey]; ok {
-			h.Ctx.Logger.WithFields(logrus.Fields{
-				"AppTrafficKey": appTrafficKey,
-				"MaxTraffic":    maxTraffic,
-			}).Debug("AppTrafficKey Check")
-
-			tokenTrafficKey := fmt.Sprintf("%s%d:%d:%s", constant.KeyTrafficPrefix, token.Id, operation.AppId, unit)
-			tokenTrafficDetailKey := fmt.Sprintf("%s%d:%d:%d:%s", constant.KeyTrafficDetailPrefix, token.Id, operation.AppId, operation.Id, unit)
-
-			h.Ctx.Logger.WithField("TokenTrafficKey", tokenTrafficKey).Debug("TokenTrafficKey Check")
-			tokenTrafficVal, err := h.Ctx.RedisDB.Get(tokenTrafficKey, "uint")
-			if err != nil && err == redis.Nil {
-				tokenTraffic = uint(0)
-				h.Ctx.RedisDB.SAdd(constant.KEY_TRAFFIC_SET+unit, tokenTrafficKey)
-			} else {
-				h.Ctx.RedisDB.SAdd(constant.KeyTrafficDetailSet+unit, tokenTrafficDetailKey)
-				tokenTraffic = tokenTrafficVal.(uint)
-			}
+		if maxTraffic, ok := trafficMap[t.KeyName()]; ok {
+			counts = append(counts, model.TrafficCount{Unit: unit, Limit: maxTraffic})
+		}
+	}
+
+	if err := model.IncrTokenTraffic(ctx, h.Ctx.RedisDB, token.Id, operation.AppId, operation.Id, counts); err != nil {
+		logger.WithField("Redis", token.Id).Info(err)
+		return h.checkAppTokenDegraded(ctx, token, operation)
+	}
+	logger.WithField("Counts", counts).Debug("Token Traffic Check")
 
-			h.Ctx.Logger.WithFields(logrus.Fields{
-				"AppTrafficKey":   appTrafficKey,
-				"TokenTrafficKey": tokenTrafficKey,
-				"MaxTraffic":      maxTraffic,
-				"TokenTraffic":    tokenTraffic,
-			}).Debug("Token Traffic Check")
-
-			if tokenTraffic < maxTraffic {
-				h.Ctx.RedisDB.Incr(tokenTrafficKey)
-				h.Ctx.RedisDB.Incr(tokenTrafficDetailKey)
-			} else {
-				isValid = false
+	// 증가된 카운터를 집계 대상으로 등록, cron에서 이력으로 저장
+	h.Ctx.RedisDB.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, count := range counts {
+			if count.Allowed {
+				pipe.SAdd(ctx, constant.KEY_TRAFFIC_SET+count.Unit, model.TokenTrafficKey(token.Id, operation.AppId, count.Unit))
+				pipe.SAdd(ctx, constant.KeyTrafficDetailSet+count.Unit, model.TokenTrafficDetailKey(token.Id, operation.AppId, operation.Id, count.Unit))
 			}
 		}
+		return nil
+	})
+
+	var isValid = true
+	var usages []TrafficUsage
+	for _, count := range counts {
+		if !count.Allowed {
+			isValid = false
+		}
+		usages = append(usages, TrafficUsage{Unit: count.Unit, Limit: count.Limit, Used: count.Used})
+	}
+
+	if isValid {
+		return grpc_author.ApiAuthRes_VALID, usages
+	}
+
+	return grpc_author.ApiAuthRes_LIMIT_EXCEEDED, usages
+}
+
+// checkAppTokenDegraded Redis 장애 시 DB에서 직접 조회
+// App의 장애 정책이 FailOpen이면 트래픽을 제한하지 않고, 아니면 인스턴스 메모리로 제한
+func (h *AppTokenHandler) checkAppTokenDegraded(ctx context.Context, token *model.Token, operation *model.Operation) (grpc_author.ApiAuthRes_Code, []TrafficUsage) {
+	logger := logging.FromContext(ctx, h.Ctx.Logger).WithField("module", "AppTokenHandler")
+
+	app := &model.App{NameSpace: operation.App.NameSpace}
+	if err := app.FindApp(ctx, h.Ctx.Orm); err != nil {
+		return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE, nil
+	}
+	operation.App = *app
+	operation.AppId = app.Id
+	if code := appStatusCode(app, time.Now()); code != grpc_author.ApiAuthRes_VALID {
+		return code, nil
+	}
+
+	if err := operation.FindOperation(ctx, h.Ctx.Orm); err != nil {
+		return grpc_author.ApiAuthRes_UNREGISTERED_SERVICE, nil
+	}
+
+	if err := token.FindByToken(ctx, h.Ctx.Orm); err != nil {
+		return grpc_author.ApiAuthRes_UNAUTHORIZED, nil
+	}
+
+	appToken := model.AppToken{TokenId: token.Id, AppId: operation.AppId}
+	if err := appToken.FindByAppAndToken(ctx, h.Ctx.Orm); err != nil {
+		return grpc_author.ApiAuthRes_UNAUTHORIZED, nil
+	}
+
+	if app.FailOpen {
+		metrics.DegradedChecks.WithLabelValues(metrics.FailPolicyOpen).Inc()
+		logger.Debug("redis unavailable, traffic limit skipped")
+		return grpc_author.ApiAuthRes_VALID, nil
+	}
+	metrics.DegradedChecks.WithLabelValues(metrics.FailPolicyClosed).Inc()
+
+	traffics, err := model.FindTrafficsByApp(ctx, h.Ctx.Orm, operation.AppId)
+	if err != nil {
+		logger.WithField("DB", appToken.Id).Info("Count not found AppToken Traffic Info")
+		return grpc_author.ApiAuthRes_UNKNOWN, nil
+	}
+
+	var isValid = true
+	var usages []TrafficUsage
+	for _, traffic := range traffics {
+		used, ok := h.Ctx.LocalLimiter.Allow(model.TokenTrafficKey(token.Id, operation.AppId, traffic.Unit), traffic.Unit, traffic.Val)
+		if !ok {
+			isValid = false
+		}
+		usages = append(usages, TrafficUsage{Unit: traffic.Unit, Limit: traffic.Val, Used: used})
 	}
+	logger.WithField("Usages", usages).Debug("redis unavailable, traffic limited locally")
 
 	if isValid {
-		return grpc_author.ApiAuthRes_VALID
+		return grpc_author.ApiAuthRes_VALID, usages
+	}
+
+	return grpc_author.ApiAuthRes_LIMIT_EXCEEDED, usages
+}
+
+// App 상태에 따른 인증 결과, API 호출을 허용하는 상태면 VALID
+// deprecated는 종료 예정 시각 전까지 허용하며, 호출 측에서 operation.App으로 사용 중단 예정 여부 확인
+func appStatusCode(app *model.App, now time.Time) grpc_author.ApiAuthRes_Code {
+	switch app.CurrentStatus() {
+	case constant.AppStatusDraft:
+		return grpc_author.ApiAuthRes_INACTIVE_SERVICE
+	case constant.AppStatusSuspended:
+		return grpc_author.ApiAuthRes_SUSPENDED_SERVICE
+	case constant.AppStatusDeprecated:
+		if app.IsSunset(now) {
+			return grpc_author.ApiAuthRes_TERMINATED_SERVICE
+		}
 	}
 
-	return grpc_author.ApiAuthRes_LIMIT_EXCEEDED
+	return grpc_author.ApiAuthRes_VALID
 }
@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"context"
 	"github.com/kekim-go/Author/app/ctx"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+	"github.com/sirupsen/logrus"
 )
 
 type AuthHandler struct {
@@ -11,3 +16,55 @@ type AuthHandler struct {
 func NewAuthHandler(ctx *ctx.Context) *AuthHandler {
 	return &AuthHandler{Ctx: ctx}
 }
+
+// RecordLogin 로그인 시도 결과를 이력으로 저장하고, 성공한 경우 회원의 로그인 통계를 갱신
+// 이력 저장 실패가 로그인 자체를 막지 않도록 오류는 로그로만 남김
+func (h *AuthHandler) RecordLogin(ctx context.Context, event *model.LoginEvent, user *model.User, result grpc_author.AuthResult) {
+	event.Result = int32(result)
+	if user != nil {
+		event.UserId = user.Id
+	}
+
+	logger := logging.FromContext(ctx, h.Ctx.Logger).WithFields(logrus.Fields{
+		"module":   "AuthHandler",
+		"function": "RecordLogin",
+	})
+
+	if err := event.Save(ctx, h.Ctx.Orm); err != nil {
+		logger.Info(err)
+	}
+
+	if user != nil && result == grpc_author.AuthResult_VALID {
+		if err := user.UpdateLoginStat(ctx, h.Ctx.Orm); err != nil {
+			logger.Info(err)
+		}
+	}
+}
+
+// RehashPassword 저장된 해시가 현재 해시 설정보다 약한 경우 로그인 비밀번호로 재암호화
+// 재암호화 실패가 로그인 자체를 막지 않도록 오류는 로그로만 남김
+func (h *AuthHandler) RehashPassword(ctx context.Context, user *model.User, password string) {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	if !h.Ctx.PasswordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	logger := logging.FromContext(ctx, h.Ctx.Logger).WithFields(logrus.Fields{
+		"module":   "AuthHandler",
+		"function": "RehashPassword",
+	})
+
+	enc, err := h.Ctx.PasswordHasher.Hash(password)
+	if err != nil {
+		logger.Info(err)
+		return
+	}
+
+	if _, err := orm.ID(user.Id).Cols("password").Update(&model.User{Password: enc}); err != nil {
+		logger.Info(err)
+		return
+	}
+	user.Password = enc
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/model"
+)
+
+// 성공한 로그인만 회원의 로그인 횟수와 마지막 로그인 시각을 갱신하고, 실패도 이력으로 남김
+func TestRecordLogin(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAuthHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "alice", "password1")
+
+	h.RecordLogin(background, &model.LoginEvent{LoginId: "alice", RemoteAddr: "10.0.0.1:5000", UserAgent: "test"}, user, grpc_author.AuthResult_INVALID_PASSWORD)
+	h.RecordLogin(background, &model.LoginEvent{LoginId: "nobody"}, nil, grpc_author.AuthResult_NOT_REGISTERED)
+
+	stored := &model.User{Id: user.Id}
+	if err := stored.Find(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if stored.LoginCount != 0 || !stored.LastLoginAt.IsZero() {
+		t.Errorf("failed login must not update stats, user = %+v", stored)
+	}
+
+	h.RecordLogin(background, &model.LoginEvent{LoginId: "alice"}, user, grpc_author.AuthResult_VALID)
+	h.RecordLogin(background, &model.LoginEvent{LoginId: "alice"}, user, grpc_author.AuthResult_VALID)
+
+	stored = &model.User{Id: user.Id}
+	if err := stored.Find(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if stored.LoginCount != 2 || stored.LastLoginAt.IsZero() {
+		t.Errorf("login stats = %d, %v, want 2 logins", stored.LoginCount, stored.LastLoginAt)
+	}
+
+	events, err := model.FindLoginEventsByUser(background, c.Orm, user.Id, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []grpc_author.AuthResult{grpc_author.AuthResult_VALID, grpc_author.AuthResult_VALID, grpc_author.AuthResult_INVALID_PASSWORD}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, event := range events {
+		if grpc_author.AuthResult(event.Result) != want[i] || event.LoginId != "alice" {
+			t.Errorf("events[%d] = %+v, want result %s", i, event, want[i])
+		}
+	}
+	if last := events[2]; last.RemoteAddr != "10.0.0.1:5000" || last.UserAgent != "test" {
+		t.Errorf("failed login event = %+v", last)
+	}
+
+	// 가입되지 않은 로그인 ID는 회원 없이 기록
+	if count := countRows(t, c, &model.LoginEvent{}, "login_id = ? AND user_id = ? AND result = ?", "nobody", 0, int32(grpc_author.AuthResult_NOT_REGISTERED)); count != 1 {
+		t.Errorf("unregistered login events = %d, want 1", count)
+	}
+}
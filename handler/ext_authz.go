@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kekim-go/Author/constant"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/model"
+)
+
+const (
+	defaultExtAuthzTokenHeader     = "x-api-key"
+	defaultExtAuthzTokenQueryParam = "serviceKey"
+	ExtAuthzNamespaceFromHost      = "host"
+	ExtAuthzCodeHeader             = "x-author-code"
+)
+
+// ExtAuthzReq : 프록시(Envoy, nginx)가 전달한 원 요청 정보, Headers의 키는 소문자
+type ExtAuthzReq struct {
+	Host    string
+	Path    string // 쿼리 문자열 포함
+	Headers map[string]string
+}
+
+// ExtAuthzRes : 프록시에 반환할 HTTP 상태와 응답 헤더
+type ExtAuthzRes struct {
+	Code    grpc_author.ApiAuthRes_Code
+	Status  int
+	Headers map[string]string
+}
+
+// ExtAuthorize 원 요청에서 API 키와 namespace/operation을 추출하여 CheckAppToken 실행
+func (h *AppTokenHandler) ExtAuthorize(ctx context.Context, req ExtAuthzReq) ExtAuthzRes {
+	config := h.Ctx.Config.ExtAuthz
+
+	u, err := url.Parse(req.Path)
+	if err != nil {
+		return newExtAuthzRes(grpc_author.ApiAuthRes_PARAMETER_EXCEPTION, http.StatusForbidden, nil)
+	}
+
+	tokenHeader := strings.ToLower(config.TokenHeader)
+	if len(tokenHeader) == 0 {
+		tokenHeader = defaultExtAuthzTokenHeader
+	}
+	tokenQueryParam := config.TokenQueryParam
+	if len(tokenQueryParam) == 0 {
+		tokenQueryParam = defaultExtAuthzTokenQueryParam
+	}
+
+	token := req.Headers[tokenHeader]
+	if len(token) == 0 {
+		token = u.Query().Get(tokenQueryParam)
+	}
+	if len(token) == 0 {
+		return newExtAuthzRes(grpc_author.ApiAuthRes_PARAMETER_EXCEPTION, http.StatusUnauthorized, nil)
+	}
+
+	nameSpace, endPoint := h.mapOperation(req.Host, u.Path)
+	if len(nameSpace) == 0 {
+		return newExtAuthzRes(grpc_author.ApiAuthRes_PARAMETER_EXCEPTION, http.StatusForbidden, nil)
+	}
+
+	operation := &model.Operation{EndPoint: endPoint, IsDel: false, App: model.App{NameSpace: nameSpace, IsDel: false}}
+	code, usages := h.CheckAppTokenUsage(ctx, &model.Token{Token: token, IsDel: false}, operation)
+
+	res := newExtAuthzRes(code, extAuthzStatus(code), usages)
+	// 사용 중단 예정 서비스는 Deprecation, Sunset(RFC 8594) 헤더 추가
+	if code == grpc_author.ApiAuthRes_VALID && operation.App.CurrentStatus() == constant.AppStatusDeprecated {
+		res.Headers["deprecation"] = "true"
+		if operation.App.SunsetAt != nil {
+			res.Headers["sunset"] = operation.App.SunsetAt.UTC().Format(http.TimeFormat)
+		}
+	}
+
+	return res
+}
+
+// 설정에 따라 host 또는 path의 첫 부분을 namespace로, 나머지 경로를 operation으로 사용
+func (h *AppTokenHandler) mapOperation(host string, path string) (string, string) {
+	config := h.Ctx.Config.ExtAuthz
+	path = strings.TrimPrefix(path, config.PathPrefix)
+
+	if config.NamespaceFrom == ExtAuthzNamespaceFromHost {
+		if i := strings.IndexAny(host, ".:"); i >= 0 {
+			host = host[:i]
+		}
+		return host, path
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i], path[i:]
+	}
+
+	return path, "/"
+}
+
+func extAuthzStatus(code grpc_author.ApiAuthRes_Code) int {
+	switch code {
+	case grpc_author.ApiAuthRes_VALID:
+		return http.StatusOK
+	case grpc_author.ApiAuthRes_UNAUTHORIZED, grpc_author.ApiAuthRes_UNREGISTERED_TOKEN:
+		return http.StatusUnauthorized
+	case grpc_author.ApiAuthRes_UNREGISTERED_SERVICE, grpc_author.ApiAuthRes_INACTIVE_SERVICE, grpc_author.ApiAuthRes_SUSPENDED_SERVICE,
+		grpc_author.ApiAuthRes_TERMINATED_SERVICE, grpc_author.ApiAuthRes_PARAMETER_EXCEPTION:
+		return http.StatusForbidden
+	case grpc_author.ApiAuthRes_LIMIT_EXCEEDED:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// 남은 호출 수가 가장 적은 단위 기준으로 X-RateLimit-* 헤더 생성
+// 허용치를 초과한 경우 초기화까지 가장 오래 남은 단위로 Retry-After 설정
+func newExtAuthzRes(code grpc_author.ApiAuthRes_Code, status int, usages []TrafficUsage) ExtAuthzRes {
+	res := ExtAuthzRes{
+		Code:    code,
+		Status:  status,
+		Headers: map[string]string{ExtAuthzCodeHeader: code.String()},
+	}
+
+	now := time.Now()
+	var selected *TrafficUsage
+	var retryAfter time.Duration
+	for i, usage := range usages {
+		if selected == nil || usage.Limit-usage.Used < selected.Limit-selected.Used {
+			selected = &usages[i]
+		}
+		if reset := trafficResetIn(usage.Unit, now); usage.Used >= usage.Limit && reset > retryAfter {
+			retryAfter = reset
+		}
+	}
+
+	if selected != nil {
+		res.Headers["x-ratelimit-limit"] = strconv.FormatUint(uint64(selected.Limit), 10)
+		res.Headers["x-ratelimit-remaining"] = strconv.FormatUint(uint64(selected.Limit-selected.Used), 10)
+		res.Headers["x-ratelimit-reset"] = strconv.Itoa(int(trafficResetIn(selected.Unit, now).Seconds()))
+	}
+	if code == grpc_author.ApiAuthRes_LIMIT_EXCEEDED && retryAfter > 0 {
+		res.Headers["retry-after"] = strconv.Itoa(int(retryAfter.Seconds()))
+	}
+
+	return res
+}
+
+// 트래픽 단위의 현재 구간이 끝날 때까지 남은 시간
+func trafficResetIn(unit string, now time.Time) time.Duration {
+	var next time.Time
+	switch unit {
+	case "hour":
+		next = now.Truncate(time.Hour).Add(time.Hour)
+	case "day":
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	case "month":
+		next = time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+	default:
+		return 0
+	}
+
+	return next.Sub(now)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/ratelimit"
+)
+
+const testApiKey = "test-api-key"
+
+// newExtAuthzContext namespace "svc"의 /items operation과 API 키를 등록, 시간당 허용치는 limit
+// 테스트 Context의 Redis는 접속할 수 없으므로 DB 조회와 인스턴스 메모리 제한으로 확인
+func newExtAuthzContext(t *testing.T, limit uint) (*ctx.Context, *model.App) {
+	t.Helper()
+
+	c := newTestContext(t)
+	c.LocalLimiter = ratelimit.NewLocalLimiter()
+
+	app := &model.App{Id: 1, NameSpace: "svc", Status: constant.AppStatusActive}
+	token := &model.Token{Token: testApiKey}
+	rows := []interface{}{
+		app,
+		&model.Operation{Id: 1, AppId: app.Id, EndPoint: "/items"},
+		token,
+		&model.Traffic{AppId: app.Id, Unit: "hour", Val: limit},
+	}
+	for _, row := range rows {
+		if _, err := c.Orm.Insert(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.Orm.Insert(&model.AppToken{AppId: app.Id, TokenId: token.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	return c, app
+}
+
+func extAuthorize(c *ctx.Context, path string, headers map[string]string) ExtAuthzRes {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	return NewAppTokenHandler(c).ExtAuthorize(context.Background(), ExtAuthzReq{Host: "gateway.example.com", Path: path, Headers: headers})
+}
+
+func TestExtAuthorizeStatus(t *testing.T) {
+	c, _ := newExtAuthzContext(t, 10)
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+		code    grpc_author.ApiAuthRes_Code
+	}{
+		{"header key", "/svc/items", map[string]string{"x-api-key": testApiKey}, http.StatusOK, grpc_author.ApiAuthRes_VALID},
+		{"query key", "/svc/items?serviceKey=" + testApiKey, nil, http.StatusOK, grpc_author.ApiAuthRes_VALID},
+		{"no key", "/svc/items", nil, http.StatusUnauthorized, grpc_author.ApiAuthRes_PARAMETER_EXCEPTION},
+		{"unknown key", "/svc/items", map[string]string{"x-api-key": "unknown"}, http.StatusUnauthorized, grpc_author.ApiAuthRes_UNAUTHORIZED},
+		{"unknown namespace", "/other/items", map[string]string{"x-api-key": testApiKey}, http.StatusForbidden, grpc_author.ApiAuthRes_UNREGISTERED_SERVICE},
+		{"unknown operation", "/svc/orders", map[string]string{"x-api-key": testApiKey}, http.StatusForbidden, grpc_author.ApiAuthRes_UNREGISTERED_SERVICE},
+		{"no namespace", "/", map[string]string{"x-api-key": testApiKey}, http.StatusForbidden, grpc_author.ApiAuthRes_PARAMETER_EXCEPTION},
+	}
+	for _, tt := range tests {
+		res := extAuthorize(c, tt.path, tt.headers)
+		if res.Status != tt.status || res.Code != tt.code {
+			t.Errorf("%s: status = %d (%s), want %d (%s)", tt.name, res.Status, res.Code, tt.status, tt.code)
+		}
+		if res.Headers[ExtAuthzCodeHeader] != tt.code.String() {
+			t.Errorf("%s: %s = %q", tt.name, ExtAuthzCodeHeader, res.Headers[ExtAuthzCodeHeader])
+		}
+	}
+}
+
+func TestExtAuthorizeRateLimit(t *testing.T) {
+	c, _ := newExtAuthzContext(t, 2)
+	headers := map[string]string{"x-api-key": testApiKey}
+
+	for i := 1; i <= 2; i++ {
+		res := extAuthorize(c, "/svc/items", headers)
+		if res.Status != http.StatusOK {
+			t.Fatalf("call %d: status = %d", i, res.Status)
+		}
+		if res.Headers["x-ratelimit-limit"] != "2" || res.Headers["x-ratelimit-remaining"] != strconv.Itoa(2-i) {
+			t.Errorf("call %d: headers = %v", i, res.Headers)
+		}
+		if _, ok := res.Headers["retry-after"]; ok {
+			t.Errorf("call %d: retry-after must not be set on allowed call", i)
+		}
+	}
+
+	res := extAuthorize(c, "/svc/items", headers)
+	if res.Status != http.StatusTooManyRequests || res.Code != grpc_author.ApiAuthRes_LIMIT_EXCEEDED {
+		t.Fatalf("status = %d (%s), want 429", res.Status, res.Code)
+	}
+	if res.Headers["x-ratelimit-remaining"] != "0" {
+		t.Errorf("x-ratelimit-remaining = %q", res.Headers["x-ratelimit-remaining"])
+	}
+
+	retryAfter, err := strconv.Atoi(res.Headers["retry-after"])
+	if err != nil || retryAfter <= 0 || retryAfter > int(time.Hour.Seconds()) {
+		t.Errorf("retry-after = %q, want seconds until next hour", res.Headers["retry-after"])
+	}
+}
+
+func TestExtAuthorizeAppStatus(t *testing.T) {
+	c, app := newExtAuthzContext(t, 10)
+	headers := map[string]string{"x-api-key": testApiKey}
+
+	setStatus := func(status string, sunsetAt *time.Time) {
+		t.Helper()
+		current := &model.App{}
+		if _, err := c.Orm.ID(app.Id).Get(current); err != nil {
+			t.Fatal(err)
+		}
+		affected, err := c.Orm.ID(app.Id).Cols("status", "sunset_at").Update(&model.App{Status: status, SunsetAt: sunsetAt, Version: current.Version})
+		if err != nil || affected != 1 {
+			t.Fatalf("update status: %d, %v", affected, err)
+		}
+	}
+
+	sunsetAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	setStatus(constant.AppStatusDeprecated, &sunsetAt)
+	res := extAuthorize(c, "/svc/items", headers)
+	if res.Status != http.StatusOK {
+		t.Fatalf("deprecated: status = %d", res.Status)
+	}
+	if res.Headers["deprecation"] != "true" || res.Headers["sunset"] != sunsetAt.UTC().Format(http.TimeFormat) {
+		t.Errorf("deprecated: headers = %v", res.Headers)
+	}
+
+	setStatus(constant.AppStatusDeprecated, nil)
+	res = extAuthorize(c, "/svc/items", headers)
+	if _, ok := res.Headers["sunset"]; res.Headers["deprecation"] != "true" || ok {
+		t.Errorf("deprecated without sunset: headers = %v", res.Headers)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		status   string
+		sunsetAt *time.Time
+		code     grpc_author.ApiAuthRes_Code
+	}{
+		{constant.AppStatusDeprecated, &past, grpc_author.ApiAuthRes_TERMINATED_SERVICE},
+		{constant.AppStatusSuspended, nil, grpc_author.ApiAuthRes_SUSPENDED_SERVICE},
+		{constant.AppStatusDraft, nil, grpc_author.ApiAuthRes_INACTIVE_SERVICE},
+	}
+	for _, tt := range tests {
+		setStatus(tt.status, tt.sunsetAt)
+		res := extAuthorize(c, "/svc/items", headers)
+		if res.Status != http.StatusForbidden || res.Code != tt.code {
+			t.Errorf("%s: status = %d (%s), want 403 (%s)", tt.status, res.Status, res.Code, tt.code)
+		}
+		if _, ok := res.Headers["deprecation"]; ok {
+			t.Errorf("%s: deprecation header must not be set on rejected call", tt.status)
+		}
+	}
+
+	setStatus(constant.AppStatusActive, nil)
+	if res := extAuthorize(c, "/svc/items", headers); res.Headers["deprecation"] != "" {
+		t.Errorf("active: headers = %v", res.Headers)
+	}
+}
+
+// host 기반 namespace와 경로 접두어 설정
+func TestExtAuthorizeMapOperation(t *testing.T) {
+	c, _ := newExtAuthzContext(t, 10)
+	h := NewAppTokenHandler(c)
+
+	c.Config.ExtAuthz = ctx.ExtAuthzConfig{PathPrefix: "/api"}
+	if ns, op := h.mapOperation("gateway.example.com", "/api/svc/items/1"); ns != "svc" || op != "/items/1" {
+		t.Errorf("path: %s %s", ns, op)
+	}
+
+	c.Config.ExtAuthz = ctx.ExtAuthzConfig{NamespaceFrom: ExtAuthzNamespaceFromHost, TokenHeader: "X-Service-Key"}
+	if ns, op := h.mapOperation("svc.example.com:8443", "/items"); ns != "svc" || op != "/items" {
+		t.Errorf("host: %s %s", ns, op)
+	}
+
+	res := h.ExtAuthorize(context.Background(), ExtAuthzReq{Host: "svc.example.com", Path: "/items", Headers: map[string]string{"x-service-key": testApiKey}})
+	if res.Status != http.StatusOK {
+		t.Errorf("host namespace with custom header: status = %d (%s)", res.Status, res.Code)
+	}
+}
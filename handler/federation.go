@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/federation"
+	"github.com/kekim-go/Author/model"
+)
+
+var federatedLoginIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,64}$`)
+
+// FederatedLogin 외부 IdP의 ID 토큰을 검증하고 연결된 회원 조회
+// 연결된 회원이 없으면 IdP 설정에 따라 같은 이메일의 기존 회원에 연결하거나 새 회원을 생성
+func (h *AuthHandler) FederatedLogin(ctx context.Context, idToken string) (*model.User, error) {
+	identity, err := h.Ctx.Federation.Verify(idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := model.FindUserIdentity(ctx, h.Ctx.Orm, identity.Issuer, identity.Subject)
+	if code, _ := errors.Decompose(err); err != nil && code != http.StatusNotFound {
+		return nil, err
+	}
+
+	var user *model.User
+	if link != nil {
+		user = &model.User{Id: link.UserId}
+		if err := user.Find(ctx, h.Ctx.Orm); err != nil {
+			return nil, err
+		}
+	} else {
+		if user, err = h.linkOrProvisionUser(ctx, identity); err != nil {
+			return nil, err
+		}
+		link = &model.UserIdentity{UserId: user.Id, Issuer: identity.Issuer, Subject: identity.Subject}
+	}
+
+	link.Email = identity.Email
+	link.LastLoginAt = time.Now()
+	if err := link.Save(ctx, h.Ctx.Orm); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (h *AuthHandler) linkOrProvisionUser(ctx context.Context, identity *federation.Identity) (*model.User, error) {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	// 이메일 소유가 IdP에서 확인된 경우에만 기존 회원에 연결
+	if identity.Config.LinkByEmail && identity.EmailVerified && len(identity.Email) > 0 {
+		user := &model.User{Email: identity.Email}
+		found, err := orm.Get(user)
+		if err != nil {
+			return nil, errors.NewWithPrefix(err, "database error")
+		}
+		if found {
+			return user, nil
+		}
+	}
+
+	if !identity.Config.AutoProvision {
+		return nil, errors.NewWithCode(http.StatusNotFound, "identity not linked")
+	}
+
+	if len(identity.Email) == 0 {
+		return nil, errors.NewWithCode(http.StatusBadRequest, "email claim required")
+	}
+
+	if has, err := model.CheckEmail(ctx, h.Ctx.Orm, identity.Email); err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	} else if has {
+		return nil, errors.NewWithCode(http.StatusConflict, "email already registered")
+	}
+
+	loginId, err := h.federatedLoginId(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// 외부 IdP로만 로그인하는 회원은 사용할 수 없는 임의의 비밀번호로 생성
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	enc, err := h.Ctx.PasswordHasher.Hash(fmt.Sprintf("%x", b))
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		LoginId:  loginId,
+		Email:    identity.Email,
+		Name:     identity.Name,
+		Password: enc,
+	}
+	if _, err := orm.Insert(user); err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return user, nil
+}
+
+// preferred_username이 사용 가능하면 그대로 사용하고, 아니면 (issuer, subject)에서 파생된 ID 사용
+func (h *AuthHandler) federatedLoginId(ctx context.Context, identity *federation.Identity) (string, error) {
+	if federatedLoginIdPattern.MatchString(identity.PreferredUsername) {
+		has, err := model.CheckLoginId(ctx, h.Ctx.Orm, identity.PreferredUsername)
+		if err != nil {
+			return "", errors.NewWithPrefix(err, "database error")
+		}
+		if !has {
+			return identity.PreferredUsername, nil
+		}
+	}
+
+	sum := sha256.Sum256([]byte(identity.Issuer + "\x00" + identity.Subject))
+
+	return fmt.Sprintf("ext-%x", sum[:8]), nil
+}
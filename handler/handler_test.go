@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	"github.com/kekim-go/Author/mailer"
+	"github.com/kekim-go/Author/migration"
+	"github.com/kekim-go/Author/policy"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testMailer 발송된 메일을 기록, err를 지정하면 발송 실패
+type testMailer struct {
+	mutex    sync.Mutex
+	messages []mailer.Message
+	err      error
+}
+
+func (m *testMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *testMailer) sent() []mailer.Message {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]mailer.Message{}, m.messages...)
+}
+
+// newTestContext 최신 스키마를 적용한 SQLite DB와 접속할 수 없는 Redis를 사용하는 Context
+func newTestContext(t *testing.T) *ctx.Context {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	entry := logrus.NewEntry(logger)
+
+	orm, err := xorm.NewEngine(ctx.DBSqlite, filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { orm.Close() })
+
+	if err := migration.New(orm, entry).Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	passwordPolicy, err := policy.NewPasswordPolicy(policy.PasswordPolicyConfig{MinLength: 8, RequireDigit: true, HistorySize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasher, err := policy.NewPasswordHasher(policy.PasswordHashConfig{BcryptCost: bcrypt.MinCost})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ctx.Context{
+		Mode:           constant.ServiceDev,
+		Logger:         entry,
+		Orm:            orm,
+		RedisDB:        database.NewRedisDB(client, 100*time.Millisecond, nil),
+		Config:         &ctx.Config{Mailer: mailer.Config{ResetPasswordUrl: "{token}", VerifyEmailUrl: "{token}"}},
+		PasswordPolicy: passwordPolicy,
+		PasswordHasher: hasher,
+		Mailer:         &testMailer{},
+	}
+}
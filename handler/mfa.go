@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/totp"
+)
+
+const defaultMfaIssuer = "Author"
+
+// EnrollMfa 새 비밀키를 발급하여 미확인 상태로 저장
+// ConfirmMfa로 확인되기 전까지는 로그인에 적용되지 않음
+func (h *AuthHandler) EnrollMfa(ctx context.Context, user *model.User) (*model.UserMfa, error) {
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(ctx, h.Ctx.Orm); err != nil {
+		if code, _ := errors.Decompose(err); code != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	if mfa.Enabled {
+		return nil, errors.NewWithCode(http.StatusConflict, "mfa already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	mfa.Secret = secret
+	mfa.LastUsedStep = 0
+
+	if err := mfa.Save(ctx, h.Ctx.Orm); err != nil {
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+func (h *AuthHandler) MfaURI(user *model.User, mfa *model.UserMfa) string {
+	issuer := h.Ctx.Config.MfaConfig.Issuer
+	if len(issuer) == 0 {
+		issuer = defaultMfaIssuer
+	}
+
+	return totp.URI(issuer, user.LoginId, mfa.Secret)
+}
+
+// ConfirmMfa 인증 앱에서 생성한 코드로 등록을 확인하고 복구 코드 발급
+func (h *AuthHandler) ConfirmMfa(ctx context.Context, user *model.User, code string) ([]string, error) {
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(ctx, h.Ctx.Orm); err != nil {
+		return nil, err
+	}
+
+	if mfa.Enabled {
+		return nil, errors.NewWithCode(http.StatusConflict, "mfa already enabled")
+	}
+
+	step, ok := totp.Validate(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid mfa code")
+	}
+
+	now := time.Now()
+	mfa.Enabled = true
+	mfa.ConfirmedAt = &now
+	mfa.LastUsedStep = step
+	if err := mfa.Save(ctx, h.Ctx.Orm); err != nil {
+		return nil, err
+	}
+
+	codes, err := genRecoveryCodes(constant.MfaRecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := model.ReplaceRecoveryCodes(ctx, h.Ctx.Orm, user.Id, codes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// VerifyMfaCode TOTP 코드 또는 미사용 복구 코드 검증
+// 이미 사용된 TOTP 주기의 코드는 재사용할 수 없음
+func (h *AuthHandler) VerifyMfaCode(ctx context.Context, user *model.User, code string) error {
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(ctx, h.Ctx.Orm); err != nil {
+		return err
+	}
+
+	if !mfa.Enabled {
+		return errors.NewWithCode(http.StatusNotFound, "mfa not enrolled")
+	}
+
+	if err := h.reserveMfaAttempt(ctx, mfa); err != nil {
+		return err
+	}
+
+	if step, ok := totp.Validate(mfa.Secret, code, time.Now()); ok {
+		if used, err := mfa.UseStep(ctx, h.Ctx.Orm, step); err != nil || used {
+			return err
+		}
+	}
+
+	used, err := model.UseRecoveryCode(ctx, h.Ctx.Orm, user.Id, code)
+	if err != nil {
+		return err
+	}
+
+	if used {
+		return mfa.ResetAttempts(ctx, h.Ctx.Orm)
+	}
+
+	// 이번 실패로 최대 횟수에 도달했으면 잠금
+	current := &model.UserMfa{UserId: user.Id}
+	if err := current.FindByUser(ctx, h.Ctx.Orm); err != nil {
+		return err
+	}
+	if current.FailedAttempts >= constant.MfaUserMaxFailures {
+		if err := current.Lock(ctx, h.Ctx.Orm, time.Now().Add(constant.MfaLockoutInterval)); err != nil {
+			return err
+		}
+	}
+
+	return errors.NewWithCode(http.StatusUnauthorized, "invalid mfa code")
+}
+
+// reserveMfaAttempt 회원별 연속 실패 횟수 확인, 잠금 기간이 지났으면 잠금 해제 후 시도 허용
+// 2단계 인증 대기 토큰별 시도 횟수와 별개로, 대기 토큰을 새로 발급받아 반복 시도하는 것을 막음
+func (h *AuthHandler) reserveMfaAttempt(ctx context.Context, mfa *model.UserMfa) error {
+	reserved, err := mfa.ReserveAttempt(ctx, h.Ctx.Orm, constant.MfaUserMaxFailures)
+	if err != nil || reserved {
+		return err
+	}
+
+	locked := errors.NewWithCode(http.StatusTooManyRequests, "too many mfa failures")
+
+	// 최대 횟수에 도달한 요청이 잠금 시각을 기록하기 전에 종료된 경우에도 잠금 기간이 적용되도록 기록
+	if mfa.LockedUntil == nil {
+		if err := mfa.Lock(ctx, h.Ctx.Orm, time.Now().Add(constant.MfaLockoutInterval)); err != nil {
+			return err
+		}
+		return locked
+	}
+
+	if time.Now().Before(*mfa.LockedUntil) {
+		return locked
+	}
+
+	if unlocked, err := mfa.Unlock(ctx, h.Ctx.Orm); err != nil || unlocked {
+		return err
+	}
+
+	return locked
+}
+
+func (h *AuthHandler) DisableMfa(ctx context.Context, user *model.User, code string) error {
+	if err := h.VerifyMfaCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(ctx, h.Ctx.Orm); err != nil {
+		return err
+	}
+
+	if err := mfa.Delete(ctx, h.Ctx.Orm); err != nil {
+		return err
+	}
+
+	return model.DeleteRecoveryCodes(ctx, h.Ctx.Orm, user.Id)
+}
+
+// NewMfaChallenge 비밀번호 확인을 마친 회원에 대해 2단계 인증 대기 토큰 발급
+func (h *AuthHandler) NewMfaChallenge(ctx context.Context, user *model.User) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	mfaToken := fmt.Sprintf("%x", b)
+
+	if _, err := h.Ctx.RedisDB.SetWithExpiration(ctx, constant.KeyMfaChallenge+mfaToken, user.Id, constant.MfaChallengeExpInterval); err != nil {
+		return "", err
+	}
+
+	return mfaToken, nil
+}
+
+// FindMfaChallenge 2단계 인증 대기 토큰에 해당하는 회원 ID 조회
+func (h *AuthHandler) FindMfaChallenge(ctx context.Context, mfaToken string) (uint, error) {
+	if len(mfaToken) == 0 {
+		return 0, errors.NewWithCode(http.StatusUnauthorized, "invalid mfa token")
+	}
+
+	userId, err := h.Ctx.RedisDB.GetUint(ctx, constant.KeyMfaChallenge+mfaToken)
+	if database.IsMiss(err) {
+		return 0, errors.NewWithCode(http.StatusUnauthorized, "invalid mfa token")
+	} else if err != nil {
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+// FailMfaChallenge 실패 횟수를 기록하고, 최대 횟수에 도달하면 대기 토큰을 폐기
+func (h *AuthHandler) FailMfaChallenge(ctx context.Context, mfaToken string) {
+	attemptKey := constant.KeyMfaAttempt + mfaToken
+
+	attempts, err := h.Ctx.RedisDB.Incr(ctx, attemptKey)
+	if err != nil {
+		logging.FromContext(ctx, h.Ctx.Logger).Info(err)
+		return
+	}
+	if attempts == 1 {
+		h.Ctx.RedisDB.Expire(ctx, attemptKey, constant.MfaChallengeExpInterval)
+	}
+
+	if attempts >= constant.MfaMaxAttempts {
+		h.DeleteMfaChallenge(ctx, mfaToken)
+	}
+}
+
+func (h *AuthHandler) DeleteMfaChallenge(ctx context.Context, mfaToken string) {
+	h.Ctx.RedisDB.Delete(ctx, constant.KeyMfaChallenge+mfaToken)
+	h.Ctx.RedisDB.Delete(ctx, constant.KeyMfaAttempt+mfaToken)
+}
+
+// xxxx-xxxx 형식의 복구 코드 생성
+func genRecoveryCodes(count int) ([]string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/totp"
+)
+
+// enrollTestMfa 2단계 인증을 활성화하고 복구 코드 반환
+func enrollTestMfa(t *testing.T, c *ctx.Context, user *model.User) []string {
+	t.Helper()
+
+	h := NewAuthHandler(c)
+	mfa, err := h.EnrollMfa(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 등록 확인은 이전 주기의 코드로 하여 현재 주기의 코드를 테스트에서 사용할 수 있도록 함
+	code, err := totp.Code(mfa.Secret, totp.Step(time.Now())-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes, err := h.ConfirmMfa(context.Background(), user, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return codes
+}
+
+func currentMfaCode(t *testing.T, c *ctx.Context, user *model.User) string {
+	t.Helper()
+
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(context.Background(), c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.Code(mfa.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return code
+}
+
+func TestVerifyMfaCodeReplay(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAuthHandler(c)
+	user := createTestUser(t, c, "alice", "password1")
+	enrollTestMfa(t, c, user)
+	code := currentMfaCode(t, c, user)
+
+	// 같은 코드로 동시에 요청해도 하나만 성공
+	var wg sync.WaitGroup
+	results := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- h.VerifyMfaCode(context.Background(), user, code)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else if errorCode(err) != http.StatusUnauthorized {
+			t.Errorf("err = %v, want 401", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d requests succeeded with the same code, want 1", succeeded)
+	}
+
+	if err := h.VerifyMfaCode(context.Background(), user, code); errorCode(err) != http.StatusUnauthorized {
+		t.Errorf("replay: err = %v, want 401", err)
+	}
+}
+
+func TestVerifyMfaCodeRecoveryCode(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAuthHandler(c)
+	user := createTestUser(t, c, "bob", "password1")
+	codes := enrollTestMfa(t, c, user)
+
+	if err := h.VerifyMfaCode(context.Background(), user, codes[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.VerifyMfaCode(context.Background(), user, codes[0]); errorCode(err) != http.StatusUnauthorized {
+		t.Errorf("reused recovery code: err = %v, want 401", err)
+	}
+	if err := h.VerifyMfaCode(context.Background(), user, codes[1]); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyMfaCodeLockout(t *testing.T) {
+	c := newTestContext(t)
+	h := NewAuthHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "carol", "password1")
+	enrollTestMfa(t, c, user)
+
+	// 성공하면 연속 실패 횟수 초기화
+	for i := 0; i < constant.MfaUserMaxFailures-1; i++ {
+		h.VerifyMfaCode(background, user, "000000")
+	}
+	if err := h.VerifyMfaCode(background, user, currentMfaCode(t, c, user)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < constant.MfaUserMaxFailures; i++ {
+		if err := h.VerifyMfaCode(background, user, "000000"); errorCode(err) != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: err = %v, want 401", i+1, err)
+		}
+	}
+
+	// 잠긴 동안에는 올바른 코드도 거부
+	mfa := &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if mfa.LockedUntil == nil {
+		t.Fatal("mfa should be locked")
+	}
+	if err := h.VerifyMfaCode(background, user, "not-a-code"); errorCode(err) != http.StatusTooManyRequests {
+		t.Fatalf("locked: err = %v, want 429", err)
+	}
+
+	// 잠금 기간이 지나면 다시 시도 가능
+	past := time.Now().Add(-time.Minute)
+	if _, err := c.Orm.ID(mfa.Id).Cols("locked_until").Update(&model.UserMfa{LockedUntil: &past}); err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.Code(mfa.Secret, totp.Step(time.Now())+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.VerifyMfaCode(background, user, code); err != nil {
+		t.Fatalf("after lockout: %v", err)
+	}
+
+	mfa = &model.UserMfa{UserId: user.Id}
+	if err := mfa.FindByUser(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if mfa.FailedAttempts != 0 || mfa.LockedUntil != nil {
+		t.Errorf("failedAttempts = %d, lockedUntil = %v, want reset", mfa.FailedAttempts, mfa.LockedUntil)
+	}
+}
@@ -0,0 +1,597 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	errors "github.com/kekim-go/Author/error"
+	"github.com/kekim-go/Author/model"
+	"xorm.io/xorm"
+)
+
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthServerError             = "server_error"
+	OAuthInvalidToken            = "invalid_token"
+	OAuthInsufficientScope       = "insufficient_scope"
+
+	PkceMethodPlain = "plain"
+	PkceMethodS256  = "S256"
+)
+
+// OAuthError RFC 6749 5.2 형식의 오류 응답
+type OAuthError struct {
+	Status      int
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(status int, code string, description string) *OAuthError {
+	return &OAuthError{Status: status, Code: code, Description: description}
+}
+
+// OAuthTokenRes token endpoint 응답
+type OAuthTokenRes struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IdToken      string `json:"id_token,omitempty"`
+}
+
+// IntrospectionRes RFC 7662 introspection 응답
+type IntrospectionRes struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientId  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Jti       string `json:"jti,omitempty"`
+}
+
+// AuthorizeReq authorization endpoint 요청 파라미터
+type AuthorizeReq struct {
+	ResponseType        string
+	ClientId            string
+	RedirectUri         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// TokenReq token endpoint 요청 파라미터
+type TokenReq struct {
+	GrantType    string
+	Scope        string
+	Code         string
+	RedirectUri  string
+	CodeVerifier string
+	RefreshToken string
+}
+
+type OAuthHandler struct {
+	Ctx *ctx.Context
+}
+
+func NewOAuthHandler(ctx *ctx.Context) *OAuthHandler {
+	return &OAuthHandler{Ctx: ctx}
+}
+
+// AuthenticateClient 클라이언트 인증, public 클라이언트는 client_id만으로 식별
+func (h *OAuthHandler) AuthenticateClient(ctx context.Context, clientId string, clientSecret string) (*model.OAuthClient, error) {
+	client := &model.OAuthClient{ClientId: clientId}
+	if err := client.FindByClientId(ctx, h.Ctx.Orm); err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound {
+			return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidClient, "unknown client")
+		}
+		return nil, err
+	}
+
+	if client.IsConfidential() {
+		if matched, _ := h.Ctx.PasswordHasher.Compare(client.ClientSecret, clientSecret); !matched {
+			return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidClient, "client authentication failed")
+		}
+	} else if len(clientSecret) > 0 {
+		return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidClient, "client authentication failed")
+	}
+
+	return client, nil
+}
+
+// FindAuthorizeClient redirect_uri 검증 전 단계, 이 단계의 오류는 redirect 없이 직접 응답해야 함
+func (h *OAuthHandler) FindAuthorizeClient(ctx context.Context, clientId string, redirectUri string) (*model.OAuthClient, error) {
+	client := &model.OAuthClient{ClientId: clientId}
+	if err := client.FindByClientId(ctx, h.Ctx.Orm); err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound {
+			return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidClient, "unknown client")
+		}
+		return nil, err
+	}
+
+	if !client.HasRedirectUri(redirectUri) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidRequest, "redirect_uri is not registered")
+	}
+
+	return client, nil
+}
+
+// ValidateAuthorize authorization 요청 검증 후 발급할 scope 반환, 동의 화면 표시 전에도 사용
+func (h *OAuthHandler) ValidateAuthorize(client *model.OAuthClient, req AuthorizeReq) (string, error) {
+	if req.ResponseType != "code" {
+		return "", newOAuthError(http.StatusBadRequest, OAuthUnsupportedResponseType, "only response_type=code is supported")
+	}
+
+	if !client.HasGrantType(model.GrantAuthorizationCode) {
+		return "", newOAuthError(http.StatusBadRequest, OAuthUnauthorizedClient, "client is not allowed to use authorization_code")
+	}
+
+	if len(req.CodeChallenge) == 0 {
+		return "", newOAuthError(http.StatusBadRequest, OAuthInvalidRequest, "code_challenge is required")
+	}
+	if len(req.CodeChallengeMethod) > 0 && req.CodeChallengeMethod != PkceMethodPlain && req.CodeChallengeMethod != PkceMethodS256 {
+		return "", newOAuthError(http.StatusBadRequest, OAuthInvalidRequest, "unsupported code_challenge_method")
+	}
+
+	scope, ok := client.AllowedScope(req.Scope)
+	if !ok {
+		return "", newOAuthError(http.StatusBadRequest, OAuthInvalidScope, "requested scope is not allowed")
+	}
+
+	return scope, nil
+}
+
+// Authorize 인증된 회원이 클라이언트에 권한을 위임하는 authorization code 발급 (PKCE 필수)
+func (h *OAuthHandler) Authorize(ctx context.Context, client *model.OAuthClient, user *model.User, req AuthorizeReq) (string, error) {
+	scope, err := h.ValidateAuthorize(client, req)
+	if err != nil {
+		return "", err
+	}
+	if len(req.CodeChallengeMethod) == 0 {
+		req.CodeChallengeMethod = PkceMethodPlain
+	}
+
+	code, err := genOAuthSecret(32)
+	if err != nil {
+		return "", err
+	}
+
+	oauthCode := &model.OAuthCode{
+		CodeHash:            model.HashOAuthSecret(code),
+		ClientId:            client.ClientId,
+		UserId:              user.Id,
+		RedirectUri:         req.RedirectUri,
+		Scope:               scope,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Nonce:               req.Nonce,
+		ExpiresAt:           time.Now().Add(constant.OAuthCodeExpInterval),
+	}
+	if err := oauthCode.Save(ctx, h.Ctx.Orm); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// Token grant_type에 따른 access token 발급
+func (h *OAuthHandler) Token(ctx context.Context, client *model.OAuthClient, req TokenReq) (*OAuthTokenRes, error) {
+	switch req.GrantType {
+	case model.GrantClientCredentials:
+		return h.clientCredentials(ctx, client, req)
+	case model.GrantAuthorizationCode:
+		return h.authorizationCode(ctx, client, req)
+	case model.GrantRefreshToken:
+		return h.refreshToken(ctx, client, req)
+	default:
+		return nil, newOAuthError(http.StatusBadRequest, OAuthUnsupportedGrantType, "unsupported grant_type")
+	}
+}
+
+func (h *OAuthHandler) clientCredentials(ctx context.Context, client *model.OAuthClient, req TokenReq) (*OAuthTokenRes, error) {
+	if !client.IsConfidential() || !client.HasGrantType(model.GrantClientCredentials) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthUnauthorizedClient, "client is not allowed to use client_credentials")
+	}
+
+	scope, ok := client.AllowedScope(req.Scope)
+	if !ok {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidScope, "requested scope is not allowed")
+	}
+
+	return h.issueToken(ctx, h.Ctx.Orm, client, nil, scope, false, "")
+}
+
+func (h *OAuthHandler) authorizationCode(ctx context.Context, client *model.OAuthClient, req TokenReq) (*OAuthTokenRes, error) {
+	if !client.HasGrantType(model.GrantAuthorizationCode) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthUnauthorizedClient, "client is not allowed to use authorization_code")
+	}
+
+	code, err := model.UseOAuthCode(ctx, h.Ctx.Orm, req.Code, client.ClientId, req.RedirectUri)
+	if err != nil {
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound || status == http.StatusConflict {
+			return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "invalid authorization code")
+		}
+		return nil, err
+	}
+
+	if !verifyPkce(code.CodeChallenge, code.CodeChallengeMethod, req.CodeVerifier) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "code_verifier does not match")
+	}
+
+	user := &model.User{Id: code.UserId}
+	if err := user.Find(ctx, h.Ctx.Orm); err != nil {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "user not found")
+	}
+
+	return h.issueToken(ctx, h.Ctx.Orm, client, user, code.Scope, true, code.Nonce)
+}
+
+// refresh token은 1회용으로, 사용시 기존 발급 내역을 폐기하고 새로 발급
+func (h *OAuthHandler) refreshToken(ctx context.Context, client *model.OAuthClient, req TokenReq) (*OAuthTokenRes, error) {
+	if !client.HasGrantType(model.GrantRefreshToken) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthUnauthorizedClient, "client is not allowed to use refresh_token")
+	}
+
+	token, err := model.FindOAuthTokenByRefreshToken(ctx, h.Ctx.Orm, req.RefreshToken)
+	if err != nil {
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+			return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "invalid refresh token")
+		}
+		return nil, err
+	}
+
+	if token.ClientId != client.ClientId || token.IsRevoked() ||
+		token.RefreshExpiresAt == nil || time.Now().After(*token.RefreshExpiresAt) {
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "invalid refresh token")
+	}
+
+	// 폐기와 새 토큰 저장을 한 트랜잭션으로 처리, 발급에 실패하면 기존 refresh token을 그대로 사용할 수 있음
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	if err := token.Revoke(ctx, session); err != nil {
+		session.Rollback()
+		if status, _ := errors.Decompose(err); status == http.StatusConflict {
+			return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "invalid refresh token")
+		}
+		return nil, err
+	}
+
+	user := &model.User{Id: token.UserId}
+	if err := user.Find(ctx, session); err != nil {
+		session.Rollback()
+		return nil, newOAuthError(http.StatusBadRequest, OAuthInvalidGrant, "user not found")
+	}
+
+	res, err := h.issueToken(ctx, session, client, user, token.Scope, true, "")
+	if err != nil {
+		session.Rollback()
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// issueToken 회원 로그인과 동일한 서명 방식으로 access token(JWT) 발급
+// user가 nil이면(client_credentials) 클라이언트 자신을 subject로 사용
+// openid scope가 포함된 회원 위임 토큰은 OIDC ID 토큰을 함께 발급
+func (h *OAuthHandler) issueToken(ctx context.Context, orm xorm.Interface, client *model.OAuthClient, user *model.User, scope string, withRefresh bool, nonce string) (*OAuthTokenRes, error) {
+	now := time.Now()
+	exp := now.Add(constant.OAuthAccessTokenExpInterval)
+
+	jti, err := genOAuthSecret(16)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &model.TokenClaims{
+		ClientId: client.ClientId,
+		Scope:    scope,
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Subject:   client.ClientId,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: exp.Unix(),
+		},
+	}
+	if user != nil {
+		claims.Id = user.Id
+		claims.LoginId = user.LoginId
+		claims.Email = user.Email
+		claims.Username = user.Name
+		claims.Subject = strconv.FormatUint(uint64(user.Id), 10)
+	}
+
+	accessToken, err := model.SignTokenClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &model.OAuthToken{
+		Jti:       jti,
+		ClientId:  client.ClientId,
+		Scope:     scope,
+		ExpiresAt: exp,
+	}
+	if user != nil {
+		token.UserId = user.Id
+	}
+
+	res := &OAuthTokenRes{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(constant.OAuthAccessTokenExpInterval.Seconds()),
+		Scope:       scope,
+	}
+
+	if withRefresh && client.HasGrantType(model.GrantRefreshToken) {
+		refreshToken, err := genOAuthSecret(32)
+		if err != nil {
+			return nil, err
+		}
+		refreshExp := now.Add(constant.OAuthRefreshTokenExpInterval)
+
+		token.RefreshTokenHash = model.HashOAuthSecret(refreshToken)
+		token.RefreshExpiresAt = &refreshExp
+		res.RefreshToken = refreshToken
+	}
+
+	if user != nil && model.HasScope(scope, model.ScopeOpenId) {
+		idClaims := model.NewIdTokenClaims(user, h.Ctx.Oidc.Issuer(), client.ClientId, nonce, scope)
+		if res.IdToken, err = h.Ctx.Oidc.Sign(idClaims); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := token.Save(ctx, orm); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UserInfo OIDC userinfo, openid scope로 발급된 유효한 access token의 회원 정보를 scope에 따라 반환
+func (h *OAuthHandler) UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	claims, err := model.ParseTokenClaims(accessToken)
+	if err != nil || len(claims.StandardClaims.Id) == 0 {
+		return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidToken, "invalid access token")
+	}
+
+	token, err := model.FindOAuthTokenByJti(ctx, h.Ctx.Orm, claims.StandardClaims.Id)
+	if err != nil {
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+			return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidToken, "invalid access token")
+		}
+		return nil, err
+	}
+
+	if token.IsRevoked() || token.UserId == 0 {
+		return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidToken, "invalid access token")
+	}
+
+	if !model.HasScope(token.Scope, model.ScopeOpenId) {
+		return nil, newOAuthError(http.StatusForbidden, OAuthInsufficientScope, "openid scope is required")
+	}
+
+	user := &model.User{Id: token.UserId}
+	if err := user.Find(ctx, h.Ctx.Orm); err != nil {
+		return nil, newOAuthError(http.StatusUnauthorized, OAuthInvalidToken, "user not found")
+	}
+
+	info := map[string]interface{}{
+		"sub": strconv.FormatUint(uint64(user.Id), 10),
+	}
+	if model.HasScope(token.Scope, model.ScopeProfile) {
+		info["name"] = user.Name
+		info["preferred_username"] = user.LoginId
+	}
+	if model.HasScope(token.Scope, model.ScopeEmail) {
+		info["email"] = user.Email
+	}
+
+	return info, nil
+}
+
+// Introspect access token(JWT) 또는 refresh token의 유효성 조회
+// OAuth2로 발급하지 않은 회원 로그인 JWT도 유효 여부를 확인
+func (h *OAuthHandler) Introspect(ctx context.Context, tokenString string) (*IntrospectionRes, error) {
+	inactive := &IntrospectionRes{Active: false}
+
+	if claims, err := model.ParseTokenClaims(tokenString); err == nil {
+		if len(claims.StandardClaims.Id) == 0 {
+			ut := model.UserToken{Jwt: tokenString}
+			if err := ut.FindUserToken(ctx, h.Ctx.Orm); err != nil {
+				return nil, err
+			}
+			if ut.Id == 0 {
+				return inactive, nil
+			}
+		} else {
+			token, err := model.FindOAuthTokenByJti(ctx, h.Ctx.Orm, claims.StandardClaims.Id)
+			if err != nil {
+				if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+					return inactive, nil
+				}
+				return nil, err
+			}
+			if token.IsRevoked() {
+				return inactive, nil
+			}
+		}
+
+		return &IntrospectionRes{
+			Active:    true,
+			Scope:     claims.Scope,
+			ClientId:  claims.ClientId,
+			Username:  claims.LoginId,
+			TokenType: "Bearer",
+			Exp:       claims.ExpiresAt,
+			Iat:       claims.IssuedAt,
+			Sub:       claims.Subject,
+			Jti:       claims.StandardClaims.Id,
+		}, nil
+	}
+
+	token, err := model.FindOAuthTokenByRefreshToken(ctx, h.Ctx.Orm, tokenString)
+	if err != nil {
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+			return inactive, nil
+		}
+		return nil, err
+	}
+
+	if token.IsRevoked() || token.RefreshExpiresAt == nil || time.Now().After(*token.RefreshExpiresAt) {
+		return inactive, nil
+	}
+
+	return &IntrospectionRes{
+		Active:    true,
+		Scope:     token.Scope,
+		ClientId:  token.ClientId,
+		TokenType: "refresh_token",
+		Exp:       token.RefreshExpiresAt.Unix(),
+		Iat:       token.CreatedAt.Unix(),
+		Jti:       token.Jti,
+	}, nil
+}
+
+// Revoke RFC 7009, access/refresh token 중 어느 것으로 요청해도 해당 발급 건 전체를 폐기
+// 존재하지 않는 토큰은 성공으로 처리
+func (h *OAuthHandler) Revoke(ctx context.Context, client *model.OAuthClient, tokenString string) error {
+	var token *model.OAuthToken
+	var err error
+
+	if claims, parseErr := model.ParseTokenClaims(tokenString); parseErr == nil && len(claims.StandardClaims.Id) > 0 {
+		token, err = model.FindOAuthTokenByJti(ctx, h.Ctx.Orm, claims.StandardClaims.Id)
+	} else {
+		token, err = model.FindOAuthTokenByRefreshToken(ctx, h.Ctx.Orm, tokenString)
+	}
+
+	if err != nil {
+		if status, _ := errors.Decompose(err); status == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if token.ClientId != client.ClientId {
+		return newOAuthError(http.StatusUnauthorized, OAuthInvalidClient, "token was not issued to this client")
+	}
+
+	if token.IsRevoked() {
+		return nil
+	}
+
+	// 그 사이 다른 요청이 먼저 폐기한 경우도 성공으로 처리
+	if err := token.Revoke(ctx, h.Ctx.Orm); err != nil {
+		if status, _ := errors.Decompose(err); status != http.StatusConflict {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateClient 클라이언트 등록, confidential 클라이언트의 비밀키 원문은 이 때만 반환
+func (h *OAuthHandler) CreateClient(ctx context.Context, client *model.OAuthClient, confidential bool) (string, error) {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	for _, grantType := range client.GrantTypes {
+		if grantType != model.GrantClientCredentials && grantType != model.GrantAuthorizationCode && grantType != model.GrantRefreshToken {
+			return "", errors.NewWithCode(http.StatusBadRequest, "unsupported grant type: "+grantType)
+		}
+	}
+	if client.HasGrantType(model.GrantClientCredentials) && !confidential {
+		return "", errors.NewWithCode(http.StatusBadRequest, "client_credentials requires a confidential client")
+	}
+	if client.HasGrantType(model.GrantAuthorizationCode) && len(client.RedirectUris) == 0 {
+		return "", errors.NewWithCode(http.StatusBadRequest, "authorization_code requires a redirect uri")
+	}
+
+	clientId, err := genOAuthSecret(16)
+	if err != nil {
+		return "", err
+	}
+	client.ClientId = clientId
+
+	var secret string
+	if confidential {
+		if secret, err = genOAuthSecret(32); err != nil {
+			return "", err
+		}
+		if client.ClientSecret, err = h.Ctx.PasswordHasher.Hash(secret); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := orm.Insert(client); err != nil {
+		return "", errors.NewWithPrefix(err, "database error")
+	}
+
+	return secret, nil
+}
+
+func (h *OAuthHandler) DeleteClient(ctx context.Context, clientId string) error {
+	client := &model.OAuthClient{ClientId: clientId}
+	if err := client.FindByClientId(ctx, h.Ctx.Orm); err != nil {
+		return err
+	}
+
+	return client.Delete(ctx, h.Ctx.Orm)
+}
+
+// RFC 7636 code_verifier 검증
+func verifyPkce(challenge string, method string, verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+
+	if method == PkceMethodS256 {
+		sum := sha256.Sum256([]byte(verifier))
+		return base64.RawURLEncoding.EncodeToString(sum[:]) == challenge
+	}
+
+	return verifier == challenge
+}
+
+func genOAuthSecret(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/model"
+)
+
+const (
+	testRedirectUri  = "https://client.example.com/callback"
+	testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func createTestOAuthClient(t *testing.T, c *ctx.Context) *model.OAuthClient {
+	t.Helper()
+
+	client := &model.OAuthClient{
+		Name:         "client",
+		RedirectUris: []string{testRedirectUri, testRedirectUri + "2"},
+		GrantTypes:   []string{model.GrantAuthorizationCode, model.GrantRefreshToken},
+		Scopes:       []string{model.ScopeProfile, model.ScopeEmail},
+	}
+	if _, err := NewOAuthHandler(c).CreateClient(context.Background(), client, false); err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func s256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestAuthorizeValidation(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	client := createTestOAuthClient(t, c)
+
+	valid := AuthorizeReq{ResponseType: "code", ClientId: client.ClientId, RedirectUri: testRedirectUri, CodeChallenge: "challenge"}
+	if scope, err := h.ValidateAuthorize(client, valid); err != nil || scope != "profile email" {
+		t.Errorf("ValidateAuthorize = %q, %v", scope, err)
+	}
+
+	tests := map[string]func(req *AuthorizeReq){
+		OAuthUnsupportedResponseType: func(req *AuthorizeReq) { req.ResponseType = "token" },
+		OAuthInvalidRequest:          func(req *AuthorizeReq) { req.CodeChallenge = "" },
+		OAuthInvalidScope:            func(req *AuthorizeReq) { req.Scope = "openid" },
+	}
+	for want, modify := range tests {
+		req := valid
+		modify(&req)
+		_, err := h.ValidateAuthorize(client, req)
+		if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != want {
+			t.Errorf("err = %v, want %s", err, want)
+		}
+	}
+
+	if _, err := h.FindAuthorizeClient(context.Background(), client.ClientId, "https://evil.example.com"); err == nil {
+		t.Error("unregistered redirect_uri must be rejected")
+	}
+}
+
+// 다른 클라이언트나 다른 redirect_uri로 요청해도 코드가 사용 처리되지 않아야 함
+func TestAuthorizationCodeExchange(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "alice", "password1")
+	client := createTestOAuthClient(t, c)
+	other := createTestOAuthClient(t, c)
+
+	code, err := h.Authorize(background, client, user, AuthorizeReq{
+		ResponseType:        "code",
+		ClientId:            client.ClientId,
+		RedirectUri:         testRedirectUri,
+		Scope:               "profile",
+		CodeChallenge:       s256(testCodeVerifier),
+		CodeChallengeMethod: PkceMethodS256,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []struct {
+		client *model.OAuthClient
+		req    TokenReq
+	}{
+		{other, TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier}},
+		{client, TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri + "2", CodeVerifier: testCodeVerifier}},
+		{client, TokenReq{GrantType: model.GrantAuthorizationCode, Code: "unknown", RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier}},
+	}
+	for _, tt := range invalid {
+		_, err := h.Token(background, tt.client, tt.req)
+		if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != OAuthInvalidGrant {
+			t.Errorf("Token(%+v) err = %v, want invalid_grant", tt.req, err)
+		}
+	}
+
+	req := TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier}
+	res, err := h.Token(background, client, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AccessToken) == 0 || len(res.RefreshToken) == 0 || res.Scope != "profile" {
+		t.Errorf("Token = %+v", res)
+	}
+
+	if _, err := h.Token(background, client, req); err == nil {
+		t.Error("authorization code must be usable only once")
+	}
+}
+
+func TestAuthorizationCodePkceMismatch(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "bob", "password1")
+	client := createTestOAuthClient(t, c)
+
+	code, err := h.Authorize(background, client, user, AuthorizeReq{
+		ResponseType: "code", ClientId: client.ClientId, RedirectUri: testRedirectUri,
+		CodeChallenge: s256(testCodeVerifier), CodeChallengeMethod: PkceMethodS256,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = h.Token(background, client, TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier[1:] + "x"})
+	if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != OAuthInvalidGrant {
+		t.Errorf("err = %v, want invalid_grant", err)
+	}
+}
+
+func TestAuthorizationCodeExpired(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "carol", "password1")
+	client := createTestOAuthClient(t, c)
+
+	code, err := h.Authorize(background, client, user, AuthorizeReq{
+		ResponseType: "code", ClientId: client.ClientId, RedirectUri: testRedirectUri,
+		CodeChallenge: s256(testCodeVerifier), CodeChallengeMethod: PkceMethodS256,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Where("code_hash = ?", model.HashOAuthSecret(code)).Cols("expires_at").Update(&model.OAuthCode{ExpiresAt: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = h.Token(background, client, TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier})
+	if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != OAuthInvalidGrant {
+		t.Errorf("err = %v, want invalid_grant", err)
+	}
+}
+
+func issueTestRefreshToken(t *testing.T, h *OAuthHandler, client *model.OAuthClient, user *model.User) string {
+	t.Helper()
+
+	background := context.Background()
+	code, err := h.Authorize(background, client, user, AuthorizeReq{
+		ResponseType: "code", ClientId: client.ClientId, RedirectUri: testRedirectUri,
+		CodeChallenge: s256(testCodeVerifier), CodeChallengeMethod: PkceMethodS256,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := h.Token(background, client, TokenReq{GrantType: model.GrantAuthorizationCode, Code: code, RedirectUri: testRedirectUri, CodeVerifier: testCodeVerifier})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res.RefreshToken
+}
+
+// refresh token은 한 번만 사용할 수 있고 새로 발급한 refresh token으로 다시 갱신
+func TestRefreshTokenRotation(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	client := createTestOAuthClient(t, c)
+	refreshToken := issueTestRefreshToken(t, h, client, createTestUser(t, c, "dave", "password1"))
+
+	res, err := h.Token(background, client, TokenReq{GrantType: model.GrantRefreshToken, RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.RefreshToken) == 0 || res.RefreshToken == refreshToken {
+		t.Errorf("refreshed token = %+v", res)
+	}
+
+	_, err = h.Token(background, client, TokenReq{GrantType: model.GrantRefreshToken, RefreshToken: refreshToken})
+	if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != OAuthInvalidGrant {
+		t.Errorf("reuse err = %v, want invalid_grant", err)
+	}
+
+	if _, err := h.Token(background, client, TokenReq{GrantType: model.GrantRefreshToken, RefreshToken: res.RefreshToken}); err != nil {
+		t.Errorf("refresh with rotated token err = %v", err)
+	}
+}
+
+// 같은 refresh token을 동시에 조회한 요청 중 하나만 폐기에 성공해야 함
+func TestRefreshTokenRevokeOnce(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	client := createTestOAuthClient(t, c)
+	refreshToken := issueTestRefreshToken(t, h, client, createTestUser(t, c, "erin", "password1"))
+
+	first, err := model.FindOAuthTokenByRefreshToken(background, c.Orm, refreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := model.FindOAuthTokenByRefreshToken(background, c.Orm, refreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := first.Revoke(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Revoke(background, c.Orm); errorCode(err) != 409 {
+		t.Errorf("second revoke err = %v, want 409", err)
+	}
+
+	// RFC 7009 폐기 요청은 이미 폐기된 토큰도 성공
+	if err := h.Revoke(background, client, refreshToken); err != nil {
+		t.Errorf("revoke revoked token err = %v", err)
+	}
+}
+
+// 새 토큰 발급에 실패하면 기존 refresh token은 폐기되지 않아야 함
+func TestRefreshTokenRollback(t *testing.T) {
+	c := newTestContext(t)
+	h := NewOAuthHandler(c)
+	background := context.Background()
+	client := createTestOAuthClient(t, c)
+	user := createTestUser(t, c, "frank", "password1")
+	refreshToken := issueTestRefreshToken(t, h, client, user)
+
+	if _, err := c.Orm.ID(user.Id).Delete(&model.User{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := h.Token(background, client, TokenReq{GrantType: model.GrantRefreshToken, RefreshToken: refreshToken})
+	if oauthErr, ok := err.(*OAuthError); !ok || oauthErr.Code != OAuthInvalidGrant {
+		t.Fatalf("err = %v, want invalid_grant", err)
+	}
+
+	token, err := model.FindOAuthTokenByRefreshToken(background, c.Orm, refreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.IsRevoked() {
+		t.Error("refresh token must not be revoked when issuing fails")
+	}
+	if count := countRows(t, c, &model.OAuthToken{}, "user_id = ?", user.Id); count != 1 {
+		t.Errorf("oauth tokens = %d, want 1", count)
+	}
+}
@@ -1,6 +1,24 @@
 package handler
 
-import "github.com/kekim-go/Author/app/ctx"
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/mailer"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/policy"
+)
 
 type UserHandler struct {
 	Ctx *ctx.Context
@@ -11,3 +29,383 @@ func NewUserHandler(ctx *ctx.Context) *UserHandler {
 		Ctx: ctx,
 	}
 }
+
+// ValidatePassword 비밀번호 정책 검사
+// 기존 회원(user != nil)인 경우 현재 비밀번호 및 최근 사용 이력과의 중복 여부도 확인
+func (h *UserHandler) ValidatePassword(ctx context.Context, user *model.User, password string) ([]policy.Violation, error) {
+	violations := h.Ctx.PasswordPolicy.Validate(password)
+
+	if user == nil || user.Id == 0 {
+		return violations, nil
+	}
+
+	hashes := []string{user.Password}
+	if size := h.Ctx.PasswordPolicy.HistorySize(); size > 0 {
+		histories, err := model.FindRecentPasswordHistories(ctx, h.Ctx.Orm, user.Id, size)
+		if err != nil {
+			return nil, err
+		}
+		for _, history := range histories {
+			hashes = append(hashes, history.Password)
+		}
+	}
+
+	for _, hash := range hashes {
+		if matched, _ := h.Ctx.PasswordHasher.Compare(hash, password); matched {
+			violations = append(violations, policy.Violation{
+				Rule: grpc_author.PasswordViolation_REUSED,
+				Msg:  "password was used recently",
+			})
+			break
+		}
+	}
+
+	return violations, nil
+}
+
+// Signup 회원 등록과 첫 비밀번호 이력 저장을 한 트랜잭션으로 처리, 정책 검사는 ValidatePassword로 선행되어야 함
+func (h *UserHandler) Signup(ctx context.Context, user *model.User, password string) error {
+	enc, err := h.Ctx.PasswordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	user.Password = enc
+
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := session.Insert(user); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	history := &model.PasswordHistory{UserId: user.Id, Password: user.Password}
+	if err := history.Save(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	return session.Commit()
+}
+
+// ChangePassword 비밀번호 변경과 이력 추가를 한 트랜잭션으로 처리, 정책 검사는 ValidatePassword로 선행되어야 함
+func (h *UserHandler) ChangePassword(ctx context.Context, user *model.User, password string) error {
+	enc, err := h.Ctx.PasswordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := session.ID(user.Id).Cols("password").Update(&model.User{Password: enc}); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	history := &model.PasswordHistory{UserId: user.Id, Password: enc}
+	if err := history.Save(ctx, session); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	if err := session.Commit(); err != nil {
+		return err
+	}
+	user.Password = enc
+
+	return nil
+}
+
+// RequestPasswordReset 가입된 이메일이면 재설정 토큰을 메일로 발송, DB에는 토큰의 해시만 저장
+// 회원 여부를 드러내지 않도록 가입되지 않은 이메일도 오류 없이 반환
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, email string) error {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	if len(email) == 0 {
+		return nil
+	}
+
+	user := &model.User{Email: email}
+	if err := user.Find(ctx, h.Ctx.Orm); err != nil {
+		if code, _ := errors.Decompose(err); code == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	if now.Before(user.ResetPasswordSentAt.Add(constant.ResetPasswordResendInterval)) {
+		return nil
+	}
+
+	token, err := genSecretToken()
+	if err != nil {
+		return err
+	}
+
+	user.ResetPasswordToken = hashSecretToken(token)
+	user.ResetPasswordSentAt = now
+	if _, err := orm.ID(user.Id).Cols("reset_password_token", "reset_password_sent_at").Update(user); err != nil {
+		return err
+	}
+
+	return h.Ctx.Mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the link below to reset your password. It expires in %s.\n\n%s\n",
+			constant.ResetPasswordExpInterval, mailer.Link(h.Ctx.Config.Mailer.ResetPasswordUrl, token)),
+	})
+}
+
+// ConfirmPasswordReset 재설정 토큰 확인 후 비밀번호 변경, 정책 위반시 변경하지 않고 위반 내역 반환
+// 토큰은 한 번만 사용할 수 있으며 변경 후 기존 로그인 토큰은 폐기
+func (h *UserHandler) ConfirmPasswordReset(ctx context.Context, token string, password string) (*model.User, []policy.Violation, error) {
+	if len(token) == 0 {
+		return nil, nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	user, err := model.FindUserByResetPasswordToken(ctx, h.Ctx.Orm, hashSecretToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if time.Now().After(user.ResetPasswordSentAt.Add(constant.ResetPasswordExpInterval)) {
+		return nil, nil, errors.NewWithCode(http.StatusUnauthorized, "password reset expired")
+	}
+
+	if violations, err := h.ValidatePassword(ctx, user, password); err != nil || len(violations) > 0 {
+		return nil, violations, err
+	}
+
+	enc, err := h.Ctx.PasswordHasher.Hash(password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, nil, err
+	}
+
+	// 같은 토큰으로 동시에 요청한 경우 먼저 반영된 요청만 적용
+	affected, err := session.ID(user.Id).Where("reset_password_token = ?", user.ResetPasswordToken).
+		Cols("password", "reset_password_token").Update(&model.User{Password: enc})
+	if err != nil {
+		session.Rollback()
+		return nil, nil, err
+	}
+	if affected == 0 {
+		session.Rollback()
+		return nil, nil, errors.NewWithCode(http.StatusUnauthorized, "password reset already used")
+	}
+	user.Password = enc
+	user.ResetPasswordToken = ""
+
+	history := &model.PasswordHistory{UserId: user.Id, Password: user.Password}
+	if err := history.Save(ctx, session); err != nil {
+		session.Rollback()
+		return nil, nil, err
+	}
+
+	if err := model.DeleteUserTokensByUser(ctx, session, user.Id); err != nil {
+		session.Rollback()
+		return nil, nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return user, nil, nil
+}
+
+func (h *UserHandler) IsAdmin(ctx context.Context, user *model.User) (bool, error) {
+	return model.HasRole(ctx, h.Ctx.Orm, user.Id, constant.RoleAdmin)
+}
+
+// FindUser 관리자 조회용, 탈퇴 회원 포함
+func (h *UserHandler) FindUser(ctx context.Context, id uint) (*model.User, error) {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	user := &model.User{}
+	found, err := orm.Unscoped().ID(id).Get(user)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	return user, nil
+}
+
+// UpdateProfile 이름은 즉시 변경하고, 이메일은 확인 대기 상태로 저장 후 VerifyEmail에서 반영
+// 확인 메일은 저장 전에 발송하여 발송에 실패하면 아무것도 변경하지 않음
+func (h *UserHandler) UpdateProfile(ctx context.Context, user *model.User, name string, email string) error {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	cols := []string{}
+
+	if len(name) > 0 && name != user.Name {
+		user.Name = name
+		cols = append(cols, "name")
+	}
+
+	// 이번 요청에서 새 확인 토큰을 발급한 경우에만 메일 발송
+	verifyToken := ""
+	if len(email) > 0 && email != user.Email {
+		if has, err := model.CheckEmail(ctx, h.Ctx.Orm, email); err != nil {
+			return err
+		} else if has {
+			return errors.NewWithCode(http.StatusConflict, "duplicate email")
+		}
+
+		token, err := genSecretToken()
+		if err != nil {
+			return err
+		}
+		verifyToken = token
+
+		now := time.Now()
+		user.PendingEmail = email
+		user.EmailVerifyToken = hashSecretToken(token)
+		user.EmailVerifySentAt = &now
+		cols = append(cols, "pending_email", "email_verify_token", "email_verify_sent_at")
+	}
+
+	if len(cols) == 0 {
+		return nil
+	}
+
+	if len(verifyToken) > 0 {
+		if err := h.sendEmailVerification(ctx, user, verifyToken); err != nil {
+			return err
+		}
+	}
+
+	if _, err := orm.ID(user.Id).Cols(cols...).Update(user); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VerifyEmail 확인 토큰이 유효하면 대기 중인 이메일을 회원 이메일로 반영
+func (h *UserHandler) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	orm, cancel := model.Session(ctx, h.Ctx.Orm)
+	defer cancel()
+
+	user, err := model.FindUserByEmailVerifyToken(ctx, h.Ctx.Orm, hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.EmailVerifySentAt == nil || time.Now().After(user.EmailVerifySentAt.Add(constant.EmailVerifyExpInterval)) {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "email verification expired")
+	}
+
+	if has, err := model.CheckEmail(ctx, h.Ctx.Orm, user.PendingEmail); err != nil {
+		return nil, err
+	} else if has {
+		return nil, errors.NewWithCode(http.StatusConflict, "duplicate email")
+	}
+
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	user.EmailVerifyToken = ""
+	user.EmailVerifySentAt = nil
+
+	cols := []string{"email", "pending_email", "email_verify_token", "email_verify_sent_at"}
+	if _, err := orm.ID(user.Id).Cols(cols...).Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAccount 회원 탈퇴(soft delete) 처리 및 로그인 토큰, API 토큰 폐기
+func (h *UserHandler) DeleteAccount(ctx context.Context, user *model.User) error {
+	session := h.Ctx.Orm.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := session.ID(user.Id).Delete(&model.User{}); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	if err := model.DeleteUserTokensByUser(ctx, session, user.Id); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	// 탈퇴한 회원의 외부 IdP 계정은 연결 해제
+	if err := model.DeleteUserIdentitiesByUser(ctx, session, user.Id); err != nil {
+		session.Rollback()
+		return err
+	}
+
+	tokens, err := model.RevokeTokensByUser(ctx, session, user.Id)
+	if err != nil {
+		session.Rollback()
+		return err
+	}
+	outbox := &database.CacheOutbox{}
+	for _, token := range tokens {
+		outbox.Add(token.KeyName())
+	}
+
+	if err := session.Commit(); err != nil {
+		return err
+	}
+
+	// 폐기된 토큰이 캐시로 인증되지 않도록 커밋 후 삭제
+	if err := outbox.Flush(ctx, h.Ctx.RedisDB); err != nil {
+		logging.FromContext(ctx, h.Ctx.Logger).WithError(err).Warn("cache invalidation failed")
+	}
+
+	return nil
+}
+
+// 확인 토큰은 메일 본문으로만 전달하고 로그에는 기록하지 않음
+func (h *UserHandler) sendEmailVerification(ctx context.Context, user *model.User, token string) error {
+	return h.Ctx.Mailer.Send(ctx, mailer.Message{
+		To:      user.PendingEmail,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Use the link below to verify your email address. It expires in %s.\n\n%s\n",
+			constant.EmailVerifyExpInterval, mailer.Link(h.Ctx.Config.Mailer.VerifyEmailUrl, token)),
+	})
+}
+
+func genSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
+
+// 비밀번호 재설정, 이메일 확인 토큰은 DB 유출시 바로 사용할 수 없도록 해시로 저장
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
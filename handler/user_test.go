@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/model"
+)
+
+func createTestUser(t *testing.T, c *ctx.Context, loginId string, password string) *model.User {
+	t.Helper()
+
+	user := &model.User{LoginId: loginId, Email: loginId + "@example.com", Name: loginId}
+	if err := NewUserHandler(c).Signup(context.Background(), user, password); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+// 테스트 Context는 재설정, 이메일 확인 링크 형식이 "{token}"이므로 본문의 마지막 줄이 토큰
+func tokenFromMail(t *testing.T, c *ctx.Context) string {
+	t.Helper()
+
+	sent := c.Mailer.(*testMailer).sent()
+	if len(sent) == 0 {
+		t.Fatal("no mail sent")
+	}
+	lines := strings.Split(strings.TrimSpace(sent[len(sent)-1].Body), "\n")
+
+	return lines[len(lines)-1]
+}
+
+func errorCode(err error) int {
+	code, _ := errors.Decompose(err)
+	return code
+}
+
+func TestRequestPasswordResetUnknownEmail(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+
+	if err := h.RequestPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if sent := c.Mailer.(*testMailer).sent(); len(sent) != 0 {
+		t.Errorf("sent %d mails, want 0", len(sent))
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "alice", "password1")
+
+	ut := &model.UserToken{UserId: user.Id, Jwt: "jwt", RefreshToken: "refresh"}
+	if _, err := c.Orm.Insert(ut); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.RequestPasswordReset(background, user.Email); err != nil {
+		t.Fatal(err)
+	}
+	token := tokenFromMail(t, c)
+
+	stored := &model.User{Id: user.Id}
+	if err := stored.Find(background, c.Orm); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.ResetPasswordToken) == 0 || stored.ResetPasswordToken == token {
+		t.Fatalf("stored reset token must be a hash of the mailed token")
+	}
+
+	// 재발송 간격 이내의 요청은 메일을 보내지 않음
+	if err := h.RequestPasswordReset(background, user.Email); err != nil {
+		t.Fatal(err)
+	}
+	if sent := c.Mailer.(*testMailer).sent(); len(sent) != 1 {
+		t.Errorf("sent %d mails, want 1", len(sent))
+	}
+
+	_, violations, err := h.ConfirmPasswordReset(background, token, "short")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) == 0 {
+		t.Error("expected policy violations for a short password")
+	}
+
+	_, violations, err = h.ConfirmPasswordReset(background, token, "password1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Rule != grpc_author.PasswordViolation_REUSED {
+		t.Errorf("violations = %v, want REUSED", violations)
+	}
+
+	updated, violations, err := h.ConfirmPasswordReset(background, token, "password2")
+	if err != nil || len(violations) > 0 {
+		t.Fatalf("ConfirmPasswordReset = %v, %v", violations, err)
+	}
+	if matched, _ := c.PasswordHasher.Compare(updated.Password, "password2"); !matched {
+		t.Error("new password does not match")
+	}
+
+	if has, err := c.Orm.Exist(&model.UserToken{UserId: user.Id}); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Error("login tokens must be revoked after reset")
+	}
+
+	if _, _, err := h.ConfirmPasswordReset(background, token, "password3"); errorCode(err) != http.StatusNotFound {
+		t.Errorf("reusing token: err = %v, want 404", err)
+	}
+}
+
+func TestPasswordResetExpired(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "bob", "password1")
+
+	if err := h.RequestPasswordReset(background, user.Email); err != nil {
+		t.Fatal(err)
+	}
+	token := tokenFromMail(t, c)
+
+	if _, err := c.Orm.ID(user.Id).Cols("reset_password_sent_at").Update(&model.User{ResetPasswordSentAt: time.Now().Add(-2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := h.ConfirmPasswordReset(background, token, "password2"); errorCode(err) != http.StatusUnauthorized {
+		t.Errorf("err = %v, want 401", err)
+	}
+}
+
+func TestSignup(t *testing.T) {
+	c := newTestContext(t)
+	user := createTestUser(t, c, "henry", "password1")
+
+	if matched, _ := c.PasswordHasher.Compare(user.Password, "password1"); !matched {
+		t.Error("stored password must be the hash of the given password")
+	}
+	if count := countRows(t, c, &model.PasswordHistory{}, "user_id = ?", user.Id); count != 1 {
+		t.Errorf("password histories = %d, want 1", count)
+	}
+}
+
+// 비밀번호 이력 저장에 실패하면 회원 등록, 비밀번호 변경 모두 반영되지 않아야 함
+func TestPasswordHistoryFailureRollsBack(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "irene", "password1")
+
+	if err := c.Orm.DropTables(new(model.PasswordHistory)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Signup(background, &model.User{LoginId: "jack", Email: "jack@example.com", Name: "jack"}, "password1"); err == nil {
+		t.Error("signup must fail when the history is not saved")
+	}
+	if count := countRows(t, c, &model.User{}, "login_id = ?", "jack"); count != 0 {
+		t.Errorf("users = %d, want 0", count)
+	}
+
+	origin := user.Password
+	if err := h.ChangePassword(background, user, "password2"); err == nil {
+		t.Error("change password must fail when the history is not saved")
+	}
+	stored, err := h.FindUser(background, user.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Password != origin || user.Password != origin {
+		t.Error("password must not be changed when the history is not saved")
+	}
+}
+
+func TestValidatePasswordHistory(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "carol", "password1")
+
+	for _, password := range []string{"password2", "password3", "password4"} {
+		if err := h.ChangePassword(background, user, password); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// historySize 3: password2~4는 재사용 불가, 그 이전의 password1은 허용
+	for password, reused := range map[string]bool{"password1": false, "password2": true, "password4": true, "password5": false} {
+		violations, err := h.ValidatePassword(background, user, password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(violations) > 0; got != reused {
+			t.Errorf("ValidatePassword(%q) reused = %v, want %v", password, got, reused)
+		}
+	}
+
+	// 신규 가입은 이력을 검사하지 않음
+	if violations, err := h.ValidatePassword(background, nil, "password4"); err != nil || len(violations) > 0 {
+		t.Errorf("ValidatePassword(nil) = %v, %v", violations, err)
+	}
+}
+
+func TestUpdateProfileEmailVerification(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "dave", "password1")
+	createTestUser(t, c, "erin", "password1")
+
+	if err := h.UpdateProfile(background, user, "", "erin@example.com"); errorCode(err) != http.StatusConflict {
+		t.Errorf("duplicate email: err = %v, want 409", err)
+	}
+
+	if err := h.UpdateProfile(background, user, "Dave", "dave@example.org"); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := c.Mailer.(*testMailer).sent()
+	if len(sent) != 1 || sent[0].To != "dave@example.org" {
+		t.Fatalf("sent = %+v, want one mail to the pending email", sent)
+	}
+	token := tokenFromMail(t, c)
+	if token == user.EmailVerifyToken || user.EmailVerifyToken != hashSecretToken(token) {
+		t.Error("only the hash of the verification token must be stored")
+	}
+
+	// 이름만 바꾸면 대기 중인 이메일로 다시 발송하지 않음
+	if err := h.UpdateProfile(background, user, "David", ""); err != nil {
+		t.Fatal(err)
+	}
+	if sent := c.Mailer.(*testMailer).sent(); len(sent) != 1 {
+		t.Errorf("sent %d mails after a name-only update, want 1", len(sent))
+	}
+
+	if _, err := h.VerifyEmail(background, user.EmailVerifyToken); errorCode(err) != http.StatusNotFound {
+		t.Errorf("stored hash used as token: err = %v, want 404", err)
+	}
+	verified, err := h.VerifyEmail(background, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified.Email != "dave@example.org" || verified.Name != "David" || len(verified.PendingEmail) > 0 {
+		t.Errorf("verified user = %+v", verified)
+	}
+
+	if _, err := h.VerifyEmail(background, token); errorCode(err) != http.StatusNotFound {
+		t.Errorf("reused token: err = %v, want 404", err)
+	}
+}
+
+// 확인 메일 발송에 실패하면 이름, 이메일 모두 변경하지 않음
+func TestUpdateProfileMailFailure(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "gina", "password1")
+	c.Mailer.(*testMailer).err = errors.New("smtp unavailable")
+
+	if err := h.UpdateProfile(background, &model.User{Id: user.Id, Name: user.Name, Email: user.Email}, "Gina", "gina@example.org"); err == nil {
+		t.Fatal("mail failure must be returned")
+	}
+
+	stored, err := h.FindUser(background, user.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != user.Name || len(stored.PendingEmail) > 0 || len(stored.EmailVerifyToken) > 0 {
+		t.Errorf("user after failed mail = %+v", stored)
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	c := newTestContext(t)
+	h := NewUserHandler(c)
+	background := context.Background()
+	user := createTestUser(t, c, "frank", "password1")
+
+	if _, err := c.Orm.Insert(&model.UserToken{UserId: user.Id, Jwt: "jwt", RefreshToken: "refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Insert(&model.Token{UserId: user.Id, Token: "api-token"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Insert(&model.Token{UserId: user.Id + 1, Token: "other-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.DeleteAccount(background, user); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (&model.User{Id: user.Id}).Find(background, c.Orm); errorCode(err) != http.StatusNotFound {
+		t.Errorf("deleted user: err = %v, want 404", err)
+	}
+	if deleted, err := h.FindUser(background, user.Id); err != nil || deleted.DeletedAt == nil {
+		t.Errorf("FindUser = %+v, %v, want soft deleted user", deleted, err)
+	}
+	if has, _ := c.Orm.Exist(&model.UserToken{UserId: user.Id}); has {
+		t.Error("login tokens must be deleted")
+	}
+	if err := (&model.Token{Token: "api-token"}).FindByToken(background, c.Orm); errorCode(err) != http.StatusNotFound {
+		t.Errorf("api token of deleted user: err = %v, want 404", err)
+	}
+	if err := (&model.Token{Token: "other-token"}).FindByToken(background, c.Orm); err != nil {
+		t.Errorf("api token of other user: err = %v", err)
+	}
+
+	// 탈퇴 회원의 로그인 ID, 이메일은 unique 인덱스에 남아 있으므로 사용 중으로 처리
+	if has, err := model.CheckLoginId(background, c.Orm, user.LoginId); err != nil || !has {
+		t.Errorf("CheckLoginId = %v, %v, want true", has, err)
+	}
+	if has, err := model.CheckEmail(background, c.Orm, user.Email); err != nil || !has {
+		t.Errorf("CheckEmail = %v, %v, want true", has, err)
+	}
+	if has, err := model.CheckLoginId(background, c.Orm, "nobody"); err != nil || has {
+		t.Errorf("CheckLoginId(unused) = %v, %v, want false", has, err)
+	}
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIdHeader 요청 ID를 주고받는 HTTP 헤더 및 gRPC 메타데이터 키
+const RequestIdHeader = "x-request-id"
+
+// 클라이언트가 보낸 요청 ID는 형식이 맞는 경우에만 사용 (로그 위조 방지)
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type requestIdKey struct{}
+
+// WithRequestId context에 요청 ID 저장
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId context에 저장된 요청 ID, 없으면 빈 문자열
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// FromContext 요청 ID와 trace ID 필드를 추가한 logger 반환
+func FromContext(ctx context.Context, logger *logrus.Entry) *logrus.Entry {
+	fields := logrus.Fields{}
+	if requestId := RequestId(ctx); len(requestId) > 0 {
+		fields["request_id"] = requestId
+	}
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.HasTraceID() {
+		fields["trace_id"] = spanContext.TraceID.String()
+	}
+
+	if len(fields) == 0 {
+		return logger
+	}
+
+	return logger.WithFields(fields)
+}
+
+// UnaryServerInterceptor x-request-id 메타데이터를 요청 ID로 사용하고 (없으면 생성) 응답 헤더로 반환
+// 요청마다 메서드, gRPC 상태 코드, 처리 시간을 기록
+func UnaryServerInterceptor(logger *logrus.Entry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestId := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIdHeader); len(values) > 0 {
+				requestId = values[0]
+			}
+		}
+		requestId = validRequestId(requestId)
+
+		ctx = WithRequestId(ctx, requestId)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIdHeader, requestId))
+
+		start := time.Now()
+		res, err := handler(ctx, req)
+
+		FromContext(ctx, logger).WithFields(logrus.Fields{
+			"method":   info.FullMethod,
+			"code":     status.Code(err).String(),
+			"duration": time.Since(start).Seconds(),
+		}).Info("grpc request")
+
+		return res, err
+	}
+}
+
+// Middleware X-Request-Id 헤더를 요청 ID로 사용하고 (없으면 생성) 응답 헤더로 반환
+// 요청마다 메서드, 경로(쿼리 제외), 응답 상태, 처리 시간을 기록
+func Middleware(logger *logrus.Entry, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := validRequestId(r.Header.Get(RequestIdHeader))
+		w.Header().Set(RequestIdHeader, requestId)
+
+		ctx := WithRequestId(r.Context(), requestId)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		start := time.Now()
+		h.ServeHTTP(recorder, r.WithContext(ctx))
+
+		FromContext(ctx, logger).WithFields(logrus.Fields{
+			"method":   r.Method,
+			"path":     r.URL.Path,
+			"status":   recorder.status,
+			"duration": time.Since(start).Seconds(),
+		}).Info("http request")
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func validRequestId(requestId string) string {
+	if requestIdPattern.MatchString(requestId) {
+		return requestId
+	}
+
+	return newRequestId()
+}
+
+func newRequestId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,92 @@
+// Package logging 로그 출력 설정, 요청 ID 전파 및 인증 정보 가림 처리
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	ModeStd  = "std"
+	ModeFile = "file"
+	ModeBoth = "both"
+
+	FormatJson = "json"
+	FormatText = "text"
+
+	defaultFileName = "log/author.log"
+)
+
+// Config : 로그 출력 설정
+type Config struct {
+	Mode       string `yaml:"mode"`   // std(표준 출력), file, both
+	Format     string `yaml:"format"` // json(기본값) 또는 text
+	Tag        string `yaml:"tag"`
+	FileName   string `yaml:"fileName"`   // file, both 모드의 로그 파일, 기본값 log/author.log
+	MaxSize    int    `yaml:"maxSize"`    // 파일 교체 기준 크기(MB), 기본값 100
+	MaxBackups int    `yaml:"maxBackups"` // 보관할 이전 파일 수, 0이면 모두 보관
+	MaxAge     int    `yaml:"maxAge"`     // 이전 파일 보관 일수, 0이면 기간 제한 없음
+	Compress   bool   `yaml:"compress"`   // 이전 파일 gzip 압축
+}
+
+// New 설정에 따른 출력 대상과 형식의 logger 생성, 반환된 io.Closer로 로그 파일을 닫음
+func New(config Config, level logrus.Level) (*logrus.Logger, io.Closer, error) {
+	logger := logrus.New()
+	logger.SetLevel(level)
+
+	var formatter logrus.Formatter
+	switch config.Format {
+	case "", FormatJson:
+		formatter = &logrus.JSONFormatter{}
+	case FormatText:
+		formatter = &logrus.TextFormatter{FullTimestamp: true}
+	default:
+		return nil, nil, fmt.Errorf("unknown logger format: %s", config.Format)
+	}
+	logger.SetFormatter(&redactFormatter{formatter: formatter})
+
+	var file *lumberjack.Logger
+	if config.Mode == ModeFile || config.Mode == ModeBoth {
+		fileName := config.FileName
+		if len(fileName) == 0 {
+			fileName = defaultFileName
+		}
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			return nil, nil, err
+		}
+
+		file = &lumberjack.Logger{
+			Filename:   fileName,
+			MaxSize:    config.MaxSize,
+			MaxBackups: config.MaxBackups,
+			MaxAge:     config.MaxAge,
+			Compress:   config.Compress,
+			LocalTime:  true,
+		}
+	}
+
+	switch config.Mode {
+	case "", ModeStd:
+		logger.Out = os.Stdout
+		return logger, nopCloser{}, nil
+	case ModeFile:
+		logger.Out = file
+	case ModeBoth:
+		logger.Out = io.MultiWriter(os.Stdout, file)
+	default:
+		return nil, nil, fmt.Errorf("unknown logger mode: %s", config.Mode)
+	}
+
+	return logger, file, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package mailer 이메일 확인, 비밀번호 재설정 등 회원에게 보내는 메일 발송
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const sendTimeout = 10 * time.Second
+
+// Config : 메일 발송 설정 (config.yaml의 mailer 항목)
+type Config struct {
+	Host     string `yaml:"host"` // SMTP 서버 주소, 비어 있으면 발송하지 않고 수신자와 제목만 로그로 기록 (dev 모드 전용)
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+
+	VerifyEmailUrl   string `yaml:"verifyEmailUrl"`   // 이메일 확인 링크, {token} 자리에 확인 토큰이 들어감
+	ResetPasswordUrl string `yaml:"resetPasswordUrl"` // 비밀번호 재설정 링크, {token} 자리에 재설정 토큰이 들어감
+}
+
+// Link 링크 형식이 없으면 토큰만 반환
+func Link(format string, token string) string {
+	if len(format) == 0 {
+		return token
+	}
+
+	return strings.ReplaceAll(format, "{token}", token)
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New Host가 설정되어 있으면 SMTP, 없으면 로그 기록용 Mailer 반환
+func New(config Config, logger *logrus.Entry) Mailer {
+	if len(config.Host) == 0 {
+		return &logMailer{logger: logger.WithField("module", "mailer")}
+	}
+
+	return &smtpMailer{config: config}
+}
+
+type smtpMailer struct {
+	config Config
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	var dialer net.Dialer
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(sendTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return err
+		}
+	}
+	if len(m.config.Username) > 0 {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.format(msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (m *smtpMailer) format(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(m.config.From))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
+
+// 헤더 값에 줄바꿈을 넣어 다른 헤더를 추가하지 못하도록 제거
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// logMailer 개발용, 본문에 토큰이 포함되므로 수신자와 제목만 기록
+type logMailer struct {
+	logger *logrus.Entry
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info("mail not sent, mailer.host is not set")
+
+	return nil
+}
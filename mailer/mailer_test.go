@@ -0,0 +1,146 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLink(t *testing.T) {
+	if got := Link("https://example.com/verify?token={token}", "abc"); got != "https://example.com/verify?token=abc" {
+		t.Errorf("Link = %q", got)
+	}
+	if got := Link("", "abc"); got != "abc" {
+		t.Errorf("Link without format = %q", got)
+	}
+}
+
+// 제목, 수신자에 줄바꿈을 넣어 헤더를 추가할 수 없어야 함
+func TestFormatHeaderInjection(t *testing.T) {
+	m := &smtpMailer{config: Config{From: "author@example.com"}}
+	data := string(m.format(Message{
+		To:      "user@example.com\r\nBcc: attacker@example.com",
+		Subject: "Hello\nBcc: attacker@example.com",
+		Body:    "line1\nline2",
+	}))
+
+	header := data[:strings.Index(data, "\r\n\r\n")]
+	for _, line := range strings.Split(header, "\r\n") {
+		if strings.HasPrefix(line, "Bcc:") {
+			t.Errorf("injected header %q", line)
+		}
+	}
+	if !strings.HasSuffix(data, "\r\n\r\nline1\r\nline2") {
+		t.Errorf("body = %q", data)
+	}
+}
+
+// 본문의 토큰은 로그에 남기지 않음
+func TestLogMailer(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	m := New(Config{}, logrus.NewEntry(logger))
+	if err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Verify", Body: "token secret-token"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "user@example.com") || strings.Contains(out.String(), "secret-token") {
+		t.Errorf("log = %q", out.String())
+	}
+}
+
+// fakeSmtp 명령을 모두 수락하고 DATA로 받은 메시지를 전달하는 SMTP 서버
+func fakeSmtp(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ready")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				reply("500 empty command")
+				continue
+			}
+			switch strings.ToUpper(fields[0]) {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	return host, portNumber, received
+}
+
+func TestSmtpMailer(t *testing.T) {
+	host, port, received := fakeSmtp(t)
+
+	m := New(Config{Host: host, Port: port, From: "author@example.com"}, logrus.NewEntry(logrus.New()))
+	if err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Verify", Body: "link"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := <-received
+	if !strings.Contains(data, "To: user@example.com\r\n") || !strings.Contains(data, "Subject: Verify\r\n") || !strings.HasSuffix(data, "\r\n\r\nlink\r\n") {
+		t.Errorf("message = %q", data)
+	}
+}
+
+func TestSmtpMailerCanceled(t *testing.T) {
+	host, port, _ := fakeSmtp(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := New(Config{Host: host, Port: port, From: "author@example.com"}, logrus.NewEntry(logrus.New()))
+	if err := m.Send(ctx, Message{To: "user@example.com", Subject: "Verify", Body: "link"}); err == nil {
+		t.Error("sent with canceled context")
+	}
+}
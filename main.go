@@ -5,8 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
-	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -18,15 +19,24 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const defaultPort = 9090
+
 var (
 	network = flag.String("network", "tcp", `one of "tcp" or "unix". Must be consistent to -endpoint`)
-	port    = flag.Int("service port", 9090, "listen port")
+	port    = flag.Int("port", 0, "gRPC listen port, overrides server.port in config.yaml")
 )
 
 func main() {
 	flag.Parse()
 
-	ctx := context.Background()
+	// SIGTERM, SIGINT 수신시 ctx가 취소되어 gRPC/HTTP 서버가 처리 중인 요청을 마친 후 종료
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	// migrate 명령은 스키마 변경만 수행하고 종료
+	if flag.Arg(0) == "migrate" {
+		os.Exit(runMigrate(ctx, flag.Args()[1:]))
+	}
 
 	ballast := make([]byte, 10<<24)
 	_ = ballast
@@ -45,19 +55,92 @@ func main() {
 	// 주기적 통계 데이터 저장 처리
 	// go runCron(a.Ctx)
 
+	if *port == 0 {
+		*port = a.Ctx.Config.ServerConfig.Port
+	}
+	if *port == 0 {
+		*port = defaultPort
+	}
+
 	a.Run(*network, fmt.Sprintf(":%d", *port))
+	a.Ctx.Logger.Info("author service stopped")
+}
+
+// runMigrate migrate up [버전] | down [버전] | status
+// up은 버전을 생략하면 마지막 버전까지, down은 버전을 생략하면 마지막 migration 하나만 되돌림
+func runMigrate(ctx context.Context, args []string) int {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, "usage: "+os.Args[0]+" migrate up [version] | down [version] | status")
+		return 2
+	}
+
+	target := -1
+	if len(args) == 2 {
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			fmt.Fprintln(os.Stderr, "invalid version: "+args[1])
+			return 2
+		}
+		target = version
+	}
+
+	migrator, closeDB, err := app.NewMigrator(ctx)
+	if err != nil {
+		log.Error("migrate initialization error: " + err.Error())
+		return 1
+	}
+	defer closeDB()
+
+	current, err := migrator.Current(ctx)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	switch args[0] {
+	case "up":
+		if target < 0 {
+			target = migrator.Latest()
+		}
+		err = migrator.Up(ctx, target)
+	case "down":
+		if target < 0 {
+			target = current - 1
+		}
+		err = migrator.Down(ctx, target)
+	case "status":
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			log.Error(err)
+			return 1
+		}
+		fmt.Printf("current: %d, latest: %d\n", current, migrator.Latest())
+		for _, migration := range pending {
+			fmt.Printf("pending: %d %s\n", migration.Version, migration.Name)
+		}
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, "unknown migrate command: "+args[0])
+		return 2
+	}
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	return 0
 }
 
 func runCron(ctx *ctx.Context) {
 	c := cron.New()
 	c.AddFunc("* * * * *", func() {
-		_, err := ctx.RedisDB.LPop(constant.KEY_TRAFFIC_QUEUE)
+		_, err := ctx.RedisDB.LPop(context.Background(), constant.KEY_TRAFFIC_QUEUE)
 		if err != nil && err == redis.Nil {
 			fmt.Println("Ignore stat ======= ")
 			return
 		}
 
-		members, err := ctx.RedisDB.SMembers(constant.KEY_TRAFFIC_SET)
+		members, err := ctx.RedisDB.SMembers(context.Background(), constant.KEY_TRAFFIC_SET)
 		if err != nil && err == redis.Nil {
 			fmt.Println("no members ======= ")
 			return
@@ -66,14 +149,11 @@ func runCron(ctx *ctx.Context) {
 		var histories []model.AppTokenHistory
 
 		for _, appTokenKey := range members {
-			cntInfo, err := ctx.RedisDB.Get(appTokenKey, "uint")
+			count, err := ctx.RedisDB.GetUint(context.Background(), appTokenKey)
 			if err == nil {
-				count := cntInfo.(uint)
 				if count > 0 {
-					ctx.RedisDB.Delete(appTokenKey)
-					temp1 := strings.Split(appTokenKey, ":")[1]
-					temp, _ := strconv.Atoi(temp1)
-					appTokenId := uint(temp)
+					ctx.RedisDB.Delete(context.Background(), appTokenKey)
+					appTokenId, _, _, _ := model.ParseTokenTrafficKey(appTokenKey)
 
 					histories = append(histories, model.AppTokenHistory{
 						AppTokenId:  appTokenId,
@@ -87,7 +167,7 @@ func runCron(ctx *ctx.Context) {
 			ctx.Orm.Insert(&histories)
 		}
 
-		ctx.RedisDB.LPush(constant.KEY_TRAFFIC_QUEUE, "1")
+		ctx.RedisDB.LPush(context.Background(), constant.KEY_TRAFFIC_QUEUE, "1")
 
 		time.Sleep(10 * 1000 * time.Millisecond)
 		fmt.Println("Run Every min: ", time.Now().String())
@@ -0,0 +1,96 @@
+// Package metrics Prometheus 지표 정의 및 수집
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "author"
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+
+	CacheApp       = "app"
+	CacheOperation = "operation"
+	CacheToken     = "token"
+	CacheAppToken  = "app_token"
+	CacheTraffic   = "traffic"
+
+	FailPolicyOpen   = "open"
+	FailPolicyClosed = "closed"
+)
+
+var (
+	// GrpcHandlingSeconds RPC별 처리 시간 및 gRPC 상태 코드
+	GrpcHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "server_handling_seconds",
+		Help:      "Latency of gRPC requests by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// HttpHandlingSeconds HTTP 엔드포인트별 처리 시간 및 응답 상태
+	HttpHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "server_handling_seconds",
+		Help:      "Latency of HTTP requests by route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "code"})
+
+	// AppTokenChecks CheckAppToken 결과 코드별 횟수
+	AppTokenChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_token_checks_total",
+		Help:      "CheckAppToken outcomes by namespace and result code.",
+	}, []string{"name_space", "code"})
+
+	// CacheLookups Redis 캐시 조회 적중 여부 (cache: app, operation, token, app_token, traffic)
+	CacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Redis cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	// RedisCircuitOpen Redis 차단기 상태, 1이면 Redis 없이 DB로 조회하는 장애 모드
+	RedisCircuitOpen = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "circuit_open",
+		Help:      "Whether the Redis circuit breaker is open (1) and degraded mode is active.",
+	})
+
+	// DegradedChecks Redis 장애 모드에서 처리한 CheckAppToken 횟수 (policy: open, closed)
+	DegradedChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_token_degraded_checks_total",
+		Help:      "CheckAppToken calls served without Redis by app fail policy.",
+	}, []string{"policy"})
+)
+
+func init() {
+	prometheus.MustRegister(GrpcHandlingSeconds, HttpHandlingSeconds, AppTokenChecks, CacheLookups, RedisCircuitOpen, DegradedChecks)
+}
+
+// Handler 기본 레지스트리(Go 런타임, 프로세스 지표 포함)의 /metrics 핸들러
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// InstrumentHandler 등록 경로(route) 단위로 HTTP 처리 시간 기록
+func InstrumentHandler(route string, handler http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(HttpHandlingSeconds.MustCurryWith(prometheus.Labels{"route": route}), handler)
+}
+
+func CacheLookup(cache string, hit bool) {
+	if hit {
+		CacheLookups.WithLabelValues(cache, CacheHit).Inc()
+	} else {
+		CacheLookups.WithLabelValues(cache, CacheMiss).Inc()
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// 전역 지표는 다른 테스트와 공유하므로 기록 전후의 차이로 비교
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+	if err := observer.(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	ok := GrpcHandlingSeconds.WithLabelValues(info.FullMethod, codes.OK.String())
+	notFound := GrpcHandlingSeconds.WithLabelValues(info.FullMethod, codes.NotFound.String())
+	okBefore, notFoundBefore := sampleCount(t, ok), sampleCount(t, notFound)
+
+	res, err := interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "res", nil
+	})
+	if res != "res" || err != nil {
+		t.Errorf("handler result = %v, %v", res, err)
+	}
+
+	_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("handler error = %v, want NotFound", err)
+	}
+
+	if got := sampleCount(t, ok) - okBefore; got != 1 {
+		t.Errorf("OK observations = %d, want 1", got)
+	}
+	if got := sampleCount(t, notFound) - notFoundBefore; got != 1 {
+		t.Errorf("NotFound observations = %d, want 1", got)
+	}
+}
+
+func TestInstrumentHandler(t *testing.T) {
+	handler := InstrumentHandler("/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test" {
+			http.NotFound(w, r)
+		}
+	}))
+	found := HttpHandlingSeconds.WithLabelValues("/test", "200")
+	notFound := HttpHandlingSeconds.WithLabelValues("/test", "404")
+	foundBefore, notFoundBefore := sampleCount(t, found), sampleCount(t, notFound)
+
+	// 경로가 달라도 등록된 route 이름으로 기록
+	for _, target := range []string{"/test", "/test/1", "/test/2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	if got := sampleCount(t, found) - foundBefore; got != 1 {
+		t.Errorf("200 observations = %d, want 1", got)
+	}
+	if got := sampleCount(t, notFound) - notFoundBefore; got != 2 {
+		t.Errorf("404 observations = %d, want 2", got)
+	}
+}
+
+func TestCacheLookup(t *testing.T) {
+	hit := CacheLookups.WithLabelValues(CacheApp, CacheHit)
+	miss := CacheLookups.WithLabelValues(CacheApp, CacheMiss)
+	hitBefore, missBefore := testutil.ToFloat64(hit), testutil.ToFloat64(miss)
+
+	CacheLookup(CacheApp, true)
+	CacheLookup(CacheApp, false)
+	CacheLookup(CacheApp, false)
+
+	if got := testutil.ToFloat64(hit) - hitBefore; got != 1 {
+		t.Errorf("hits = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(miss) - missBefore; got != 2 {
+		t.Errorf("misses = %v, want 2", got)
+	}
+}
+
+func TestRegisterPoolCollector(t *testing.T) {
+	orm, err := xorm.NewEngine("sqlite3", filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer orm.Close()
+	if err := orm.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	if err := RegisterPoolCollector(orm, database.NewRedisDB(client, 100*time.Millisecond, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterPoolCollector(orm, database.NewRedisDB(client, 100*time.Millisecond, nil)); err == nil {
+		t.Error("registered pool collector twice")
+	}
+
+	count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "author_db_open_connections", "author_redis_pool_total_connections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("pool metrics = %d, want 2", count)
+	}
+}
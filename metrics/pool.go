@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/kekim-go/Author/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"xorm.io/xorm"
+)
+
+// poolCollector DB(database/sql) 및 Redis 연결 풀 상태를 수집 시점에 조회
+type poolCollector struct {
+	orm     *xorm.Engine
+	redisDB *database.RedisDB
+
+	dbOpen, dbInUse, dbIdle, dbWaitCount, dbWaitSeconds *prometheus.Desc
+	redisTotal, redisIdle, redisStale, redisHits        *prometheus.Desc
+	redisMisses, redisTimeouts                          *prometheus.Desc
+}
+
+// RegisterPoolCollector DB, Redis 연결 풀 지표 등록
+func RegisterPoolCollector(orm *xorm.Engine, redisDB *database.RedisDB) error {
+	db := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", name), help, nil, nil)
+	}
+	redis := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "redis", name), help, nil, nil)
+	}
+
+	return prometheus.Register(&poolCollector{
+		orm:     orm,
+		redisDB: redisDB,
+
+		dbOpen:        db("open_connections", "Number of established DB connections, both in use and idle."),
+		dbInUse:       db("in_use_connections", "Number of DB connections currently in use."),
+		dbIdle:        db("idle_connections", "Number of idle DB connections."),
+		dbWaitCount:   db("wait_count_total", "Total number of DB connections waited for."),
+		dbWaitSeconds: db("wait_duration_seconds_total", "Total time blocked waiting for a new DB connection."),
+
+		redisTotal:    redis("pool_total_connections", "Number of total connections in the Redis pool."),
+		redisIdle:     redis("pool_idle_connections", "Number of idle connections in the Redis pool."),
+		redisStale:    redis("pool_stale_connections_total", "Number of stale connections removed from the Redis pool."),
+		redisHits:     redis("pool_hits_total", "Number of times a free connection was found in the Redis pool."),
+		redisMisses:   redis("pool_misses_total", "Number of times a free connection was not found in the Redis pool."),
+		redisTimeouts: redis("pool_timeouts_total", "Number of times a wait timeout occurred in the Redis pool."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		c.dbOpen, c.dbInUse, c.dbIdle, c.dbWaitCount, c.dbWaitSeconds,
+		c.redisTotal, c.redisIdle, c.redisStale, c.redisHits, c.redisMisses, c.redisTimeouts,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	db := c.orm.DB().Stats()
+	ch <- prometheus.MustNewConstMetric(c.dbOpen, prometheus.GaugeValue, float64(db.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.dbInUse, prometheus.GaugeValue, float64(db.InUse))
+	ch <- prometheus.MustNewConstMetric(c.dbIdle, prometheus.GaugeValue, float64(db.Idle))
+	ch <- prometheus.MustNewConstMetric(c.dbWaitCount, prometheus.CounterValue, float64(db.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.dbWaitSeconds, prometheus.CounterValue, db.WaitDuration.Seconds())
+
+	redis := c.redisDB.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.redisTotal, prometheus.GaugeValue, float64(redis.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.redisIdle, prometheus.GaugeValue, float64(redis.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.redisStale, prometheus.CounterValue, float64(redis.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.redisHits, prometheus.CounterValue, float64(redis.Hits))
+	ch <- prometheus.MustNewConstMetric(c.redisMisses, prometheus.CounterValue, float64(redis.Misses))
+	ch <- prometheus.MustNewConstMetric(c.redisTimeouts, prometheus.CounterValue, float64(redis.Timeouts))
+}
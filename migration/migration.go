@@ -0,0 +1,258 @@
+// Package migration 번호가 매겨진 DB 스키마 변경(migration) 적용 및 되돌리기
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kekim-go/Author/database"
+	"github.com/sirupsen/logrus"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// 여러 인스턴스가 동시에 migration을 실행하지 않도록 사용하는 advisory lock 이름과 대기 시간
+const (
+	lockName    = "author_schema_migration"
+	lockId      = 6120930017
+	lockTimeout = 60 * time.Second
+)
+
+// Migration 한 단계의 스키마 변경, Version은 1부터 1씩 증가
+// 모델 struct가 바뀌어도 결과가 달라지지 않도록 Up/Down에서는 model 패키지의 struct를 사용하지 않음
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(session *Session) error
+	Down    func(session *Session) error
+}
+
+// Session migration 트랜잭션, DB별로 다른 SQL이 필요한 경우 DBType으로 구분
+type Session struct {
+	*xorm.Session
+	DBType schemas.DBType
+}
+
+// SchemaVersion 적용된 migration 기록
+type SchemaVersion struct {
+	Version   int `xorm:"pk"`
+	Name      string
+	AppliedAt time.Time `xorm:"created"`
+}
+
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+type Migrator struct {
+	orm        *xorm.Engine
+	logger     *logrus.Entry
+	migrations []Migration
+}
+
+func New(orm *xorm.Engine, logger *logrus.Entry) *Migrator {
+	return &Migrator{
+		orm:        orm,
+		logger:     logger.WithField("module", "migration"),
+		migrations: migrations,
+	}
+}
+
+// Latest 등록된 마지막 migration 버전
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Current 마지막으로 적용된 migration 버전, 적용 이력이 없으면 0
+func (m *Migrator) Current(ctx context.Context) (int, error) {
+	if err := m.orm.Context(ctx).Sync2(new(SchemaVersion)); err != nil {
+		return 0, err
+	}
+
+	version := &SchemaVersion{}
+	if _, err := m.orm.Context(ctx).Desc("version").Get(version); err != nil {
+		return 0, err
+	}
+
+	return version.Version, nil
+}
+
+// Pending 아직 적용되지 않은 migration 목록
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	current, err := m.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if migration.Version > current {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up target 버전까지 적용, target이 0이면 마지막 버전까지 적용
+func (m *Migrator) Up(ctx context.Context, target int) error {
+	if target == 0 {
+		target = m.Latest()
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := m.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= current || migration.Version > target {
+			continue
+		}
+
+		m.logger.WithFields(logrus.Fields{"version": migration.Version, "name": migration.Name}).Info("apply migration")
+		if err := m.apply(ctx, migration, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down target 버전이 될 때까지 적용된 migration을 역순으로 되돌림
+func (m *Migrator) Down(ctx context.Context, target int) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := m.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+
+		m.logger.WithFields(logrus.Fields{"version": migration.Version, "name": migration.Name}).Info("revert migration")
+		if err := m.apply(ctx, migration, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 한 migration을 트랜잭션 안에서 적용하고 schema_version 갱신
+// MySQL은 DDL 실행시 트랜잭션이 자동 커밋되므로 실패한 migration은 직접 확인 후 복구 필요
+func (m *Migrator) apply(ctx context.Context, migration Migration, up bool) error {
+	session := &Session{Session: m.orm.NewSession().Context(ctx), DBType: m.orm.Dialect().URI().DBType}
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	var err error
+	if up {
+		if err = migration.Up(session); err == nil {
+			_, err = session.Insert(&SchemaVersion{Version: migration.Version, Name: migration.Name})
+		}
+	} else {
+		if migration.Down == nil {
+			err = fmt.Errorf("irreversible migration")
+		} else if err = migration.Down(session); err == nil {
+			_, err = session.Delete(&SchemaVersion{Version: migration.Version})
+		}
+	}
+	if err != nil {
+		session.Rollback()
+		return fmt.Errorf("migration %d(%s): %w", migration.Version, migration.Name, err)
+	}
+
+	return session.Commit()
+}
+
+// 다른 인스턴스의 migration이 끝날 때까지 lockTimeout만큼 기다려 advisory lock 획득
+// SQLite는 쓰기 트랜잭션이 DB 파일 단위로 직렬화되므로 별도 lock을 사용하지 않음
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	unlock, acquired, err := database.AdvisoryLock(ctx, m.orm, lockName, lockId, lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, fmt.Errorf("timeout waiting for migration lock")
+	}
+
+	return func() {
+		if err := unlock(); err != nil {
+			m.logger.Warn(err)
+		}
+	}, nil
+}
+
+// dropColumns table의 columns 삭제, previous는 컬럼 추가 전 스냅샷 struct
+// SQLite는 3.35 이전 버전에서 DROP COLUMN을 지원하지 않으므로 previous로 테이블을 다시 만들고 데이터를 옮김
+func (session *Session) dropColumns(table string, previous interface{}, columns ...string) error {
+	if session.DBType != schemas.SQLITE {
+		for _, column := range columns {
+			if _, err := session.Exec("ALTER TABLE " + table + " DROP COLUMN " + column); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	old := table + "_old"
+	if _, err := session.Exec("ALTER TABLE `" + table + "` RENAME TO `" + old + "`"); err != nil {
+		return err
+	}
+
+	// 인덱스 이름은 DB 단위로 고유하므로 새 테이블의 인덱스를 만들기 전에 삭제
+	indexes, err := session.QueryString("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", old)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if _, err := session.Exec("DROP INDEX `" + index["name"] + "`"); err != nil {
+			return err
+		}
+	}
+
+	if err := session.Sync2(previous); err != nil {
+		return err
+	}
+
+	infos, err := session.QueryString("PRAGMA table_info(`" + table + "`)")
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, "`"+info["name"]+"`")
+	}
+	cols := strings.Join(names, ", ")
+
+	if _, err := session.Exec("INSERT INTO `" + table + "` (" + cols + ") SELECT " + cols + " FROM `" + old + "`"); err != nil {
+		return err
+	}
+	_, err = session.Exec("DROP TABLE `" + old + "`")
+
+	return err
+}
@@ -0,0 +1,189 @@
+package migration
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *xorm.Engine) {
+	t.Helper()
+
+	orm, err := xorm.NewEngine("sqlite3", filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { orm.Close() })
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return New(orm, logrus.NewEntry(logger)), orm
+}
+
+func columns(t *testing.T, orm *xorm.Engine, table string) map[string]bool {
+	t.Helper()
+
+	infos, err := orm.QueryString("PRAGMA table_info(`" + table + "`)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := map[string]bool{}
+	for _, info := range infos {
+		result[info["name"]] = true
+	}
+
+	return result
+}
+
+func assertVersion(t *testing.T, m *Migrator, want int) {
+	t.Helper()
+
+	current, err := m.Current(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != want {
+		t.Fatalf("current = %d, want %d", current, want)
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	m, orm := newTestMigrator(t)
+	ctx := context.Background()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, m, m.Latest())
+	if pending, err := m.Pending(ctx); err != nil || len(pending) > 0 {
+		t.Errorf("pending = %v, %v", pending, err)
+	}
+
+	// 모든 migration을 하나씩 되돌린 후 다시 적용
+	for version := m.Latest() - 1; version >= 0; version-- {
+		if err := m.Down(ctx, version); err != nil {
+			t.Fatalf("down to %d: %v", version, err)
+		}
+		assertVersion(t, m, version)
+	}
+	if exist, err := orm.IsTableExist("app"); err != nil || exist {
+		t.Errorf("app table must be dropped, exist = %v, %v", exist, err)
+	}
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, m, m.Latest())
+}
+
+func TestUpTarget(t *testing.T) {
+	m, orm := newTestMigrator(t)
+	ctx := context.Background()
+
+	if err := m.Up(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, m, 1)
+	if columns(t, orm, "app")["status"] {
+		t.Error("status column must not exist at version 1")
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil || len(pending) != m.Latest()-1 || pending[0].Version != 2 {
+		t.Errorf("pending = %v, %v", pending, err)
+	}
+}
+
+// SQLite에서 컬럼을 삭제하는 down은 테이블을 다시 만들며 데이터와 인덱스를 유지해야 함
+func TestDownDropColumnsKeepsData(t *testing.T) {
+	m, orm := newTestMigrator(t)
+	ctx := context.Background()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := orm.Exec("INSERT INTO app (id, name_space, is_del, fail_open, status, version) VALUES (1, 'svc', 0, 1, 'suspended', 3)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orm.Exec("INSERT INTO user_mfa (user_id, secret, enabled, last_used_step, failed_attempts) VALUES (7, 'secret', 1, 42, 3)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Down(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, m, 1)
+
+	if cols := columns(t, orm, "app"); cols["status"] || cols["sunset_at"] || !cols["fail_open"] {
+		t.Errorf("app columns = %v", cols)
+	}
+	if cols := columns(t, orm, "user_mfa"); cols["failed_attempts"] || cols["locked_until"] || !cols["secret"] {
+		t.Errorf("user_mfa columns = %v", cols)
+	}
+
+	apps, err := orm.QueryString("SELECT name_space, fail_open, version FROM app WHERE id = 1")
+	if err != nil || len(apps) != 1 || apps[0]["name_space"] != "svc" || apps[0]["fail_open"] != "1" || apps[0]["version"] != "3" {
+		t.Errorf("app = %v, %v", apps, err)
+	}
+	mfas, err := orm.QueryString("SELECT user_id, secret, last_used_step FROM user_mfa")
+	if err != nil || len(mfas) != 1 || mfas[0]["user_id"] != "7" || mfas[0]["secret"] != "secret" || mfas[0]["last_used_step"] != "42" {
+		t.Errorf("user_mfa = %v, %v", mfas, err)
+	}
+
+	// unique 인덱스 유지
+	if _, err := orm.Exec("INSERT INTO app (id, name_space) VALUES (2, 'svc')"); err == nil {
+		t.Error("name_space unique index must be recreated")
+	}
+	if exist, err := orm.IsTableExist("app_old"); err != nil || exist {
+		t.Errorf("app_old must be dropped, exist = %v, %v", exist, err)
+	}
+
+	// 다시 적용하면 기존 App은 active
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	apps, err = orm.QueryString("SELECT status FROM app WHERE id = 1")
+	if err != nil || len(apps) != 1 || apps[0]["status"] != "active" {
+		t.Errorf("app after up = %v, %v", apps, err)
+	}
+}
+
+// 실패한 migration은 되돌려지고 schema_version에 기록되지 않음
+func TestFailedMigrationRollsBack(t *testing.T) {
+	m, orm := newTestMigrator(t)
+	ctx := context.Background()
+
+	m.migrations = append(append([]Migration{}, m.migrations...), Migration{
+		Version: m.Latest() + 1,
+		Name:    "broken",
+		Up: func(session *Session) error {
+			if _, err := session.Exec("CREATE TABLE broken (id INTEGER)"); err != nil {
+				return err
+			}
+			_, err := session.Exec("INSERT INTO missing VALUES (1)")
+			return err
+		},
+	})
+
+	if err := m.Up(ctx, 0); err == nil {
+		t.Fatal("broken migration must fail")
+	}
+	assertVersion(t, m, m.Latest()-1)
+	if exist, err := orm.IsTableExist("broken"); err != nil || exist {
+		t.Errorf("broken table must be rolled back, exist = %v, %v", exist, err)
+	}
+
+	if err := m.Down(ctx, m.Latest()-2); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, m, m.Latest()-2)
+}
@@ -0,0 +1,11 @@
+package migration
+
+// 등록된 migration 목록, 새 migration은 버전 순서대로 마지막에 추가
+// 이미 배포된 migration은 수정하지 않고 새 버전으로 변경 사항을 추가
+var migrations = []Migration{
+	v001Baseline,
+	v002AppStatus,
+	v003TrafficArchive,
+	v004MfaLockout,
+	v005TokenUser,
+}
@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kekim-go/Author/model"
+	"xorm.io/xorm/schemas"
+)
+
+// migration으로 만든 스키마가 모델 struct와 같은지 확인하기 위한 테이블 모델 목록
+func modelTables() []interface{} {
+	return []interface{}{
+		new(model.App), new(model.Token), new(model.AppToken), new(model.AppTokenHistory),
+		new(model.Operation), new(model.Traffic), new(model.TrafficArchive), new(model.Group), new(model.User),
+		new(model.UserToken), new(model.Role), new(model.UserRole), new(model.LoginEvent),
+		new(model.PasswordHistory), new(model.UserMfa), new(model.MfaRecoveryCode),
+		new(model.OAuthClient), new(model.OAuthCode), new(model.OAuthToken), new(model.UserIdentity),
+	}
+}
+
+// 인덱스를 이름과 관계없이 종류와 컬럼으로 비교
+func indexKeys(indexes map[string]*schemas.Index) []string {
+	var keys []string
+	for _, index := range indexes {
+		kind := "index"
+		if index.Type == schemas.UniqueType {
+			kind = "unique"
+		}
+		keys = append(keys, kind+"("+strings.Join(index.Cols, ",")+")")
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// 모든 migration을 적용한 스키마의 테이블, 컬럼, 인덱스가 모델과 같아야 함
+func TestSchemaMatchesModels(t *testing.T) {
+	m, orm := newTestMigrator(t)
+	if err := m.Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	metas, err := orm.DBMetas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbTables := map[string]*schemas.Table{}
+	for _, table := range metas {
+		dbTables[table.Name] = table
+	}
+	delete(dbTables, "schema_version")
+
+	for _, bean := range modelTables() {
+		table, err := orm.TableInfo(bean)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dbTable, ok := dbTables[table.Name]
+		if !ok {
+			t.Errorf("table %s is not migrated", table.Name)
+			continue
+		}
+		delete(dbTables, table.Name)
+
+		modelColumns := table.ColumnsSeq()
+		dbColumns := dbTable.ColumnsSeq()
+		sort.Strings(modelColumns)
+		sort.Strings(dbColumns)
+		if strings.Join(modelColumns, ",") != strings.Join(dbColumns, ",") {
+			t.Errorf("%s columns = %v, model = %v", table.Name, dbColumns, modelColumns)
+		}
+
+		if model, db := indexKeys(table.Indexes), indexKeys(dbTable.Indexes); strings.Join(model, ",") != strings.Join(db, ",") {
+			t.Errorf("%s indexes = %v, model = %v", table.Name, db, model)
+		}
+	}
+
+	for name := range dbTables {
+		t.Errorf("table %s has no model", name)
+	}
+}
@@ -0,0 +1,265 @@
+package migration
+
+import "time"
+
+// v001Baseline 기존에 기동시 Sync2로 생성하던 스키마
+// 기존 DB에 적용하면 Sync2와 같이 없는 테이블, 컬럼, 인덱스만 추가됨
+var v001Baseline = Migration{
+	Version: 1,
+	Name:    "baseline",
+	Up: func(session *Session) error {
+		return session.Sync2(baselineTables()...)
+	},
+	Down: func(session *Session) error {
+		tables := baselineTables()
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := session.DropTable(tables[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func baselineTables() []interface{} {
+	return []interface{}{
+		new(baselineApp), new(baselineToken), new(baselineAppToken), new(baselineAppTokenHistory),
+		new(baselineOperation), new(baselineTraffic), new(baselineGroup), new(baselineUser),
+		new(baselineUserToken), new(baselineRole), new(baselineUserRole), new(baselineLoginEvent),
+		new(baselinePasswordHistory), new(baselineUserMfa), new(baselineMfaRecoveryCode),
+		new(baselineOAuthClient), new(baselineOAuthCode), new(baselineOAuthToken), new(baselineUserIdentity),
+	}
+}
+
+type baselineApp struct {
+	Id        uint   `xorm:"pk"`
+	NameSpace string `xorm:"unique"`
+	IsDel     bool
+	FailOpen  bool       `xorm:"default false"`
+	Version   int        `xorm:"version"`
+	CreatedAt time.Time  `xorm:"created"`
+	UpdatedAt time.Time  `xorm:"updated"`
+	DeletedAt *time.Time `xorm:"deleted index"`
+}
+
+func (baselineApp) TableName() string { return "app" }
+
+type baselineToken struct {
+	Id        uint   `xorm:"pk autoincr"`
+	Token     string `xorm:"unique"`
+	IsDel     bool
+	CreatedAt time.Time `xorm:"created"`
+	DeletedAt *time.Time
+}
+
+func (baselineToken) TableName() string { return "token" }
+
+type baselineAppToken struct {
+	Id        uint      `xorm:"pk autoincr"`
+	AppId     uint      `xorm:"index"`
+	TokenId   uint      `xorm:"index"`
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (baselineAppToken) TableName() string { return "app_token" }
+
+type baselineAppTokenHistory struct {
+	Id          uint `xorm:"pk autoincr"`
+	AppTokenId  uint `xorm:"index"`
+	OperationId uint `xorm:"index"`
+	CallTraffic uint
+	CreatedAt   time.Time `xorm:"created"`
+}
+
+func (baselineAppTokenHistory) TableName() string { return "app_token_history" }
+
+type baselineOperation struct {
+	Id        uint `xorm:"pk"`
+	AppId     uint `xorm:"index"`
+	EndPoint  string
+	IsDel     bool
+	Version   int        `xorm:"version"`
+	CreatedAt time.Time  `xorm:"created"`
+	UpdatedAt time.Time  `xorm:"updated"`
+	DeletedAt *time.Time `xorm:"deleted index"`
+}
+
+func (baselineOperation) TableName() string { return "operation" }
+
+type baselineTraffic struct {
+	Id        uint   `xorm:"pk autoincr"`
+	AppId     uint   `xorm:"index index(with_seq)"`
+	Unit      string `xorm:"varchar(10) index default 'd'"`
+	Val       uint
+	Seq       uint      `xorm:"index(with_seq)"`
+	CreatedAt time.Time `xorm:"created"`
+	UpdatedAt time.Time `xorm:"updated"`
+	DeletedAt *time.Time
+}
+
+func (baselineTraffic) TableName() string { return "traffic" }
+
+type baselineGroup struct {
+	Id        uint `xorm:"pk autoincr"`
+	Name      string
+	CreatedAt time.Time  `xorm:"created"`
+	UpdatedAt time.Time  `xorm:"updated"`
+	DeletedAt *time.Time `xorm:"deleted"`
+}
+
+func (baselineGroup) TableName() string { return "group" }
+
+type baselineUser struct {
+	Id                  uint   `xorm:"pk autoincr"`
+	GroupId             uint   `xorm:"index"`
+	LoginId             string `xorm:"unique"`
+	Password            string
+	Email               string `xorm:"unique"`
+	Name                string
+	LoginCount          uint
+	LastLoginAt         time.Time
+	ResetPasswordToken  string
+	ResetPasswordSentAt time.Time
+	PendingEmail        string
+	EmailVerifyToken    string `xorm:"index"`
+	EmailVerifySentAt   *time.Time
+	CreatedAt           time.Time  `xorm:"created"`
+	UpdatedAt           time.Time  `xorm:"updated"`
+	DeletedAt           *time.Time `xorm:"deleted index"`
+}
+
+func (baselineUser) TableName() string { return "user" }
+
+type baselineUserToken struct {
+	Id                    uint   `xorm:"pk autoincr"`
+	UserId                uint   `xorm:"index"`
+	Jwt                   string `xorm:"unique"`
+	RefreshToken          string `xorm:"unique"`
+	JwtExpiredAt          *time.Time
+	RefreshTokenExpiredAt *time.Time
+	CreatedAt             time.Time `xorm:"created"`
+	UpdatedAt             time.Time `xorm:"updated"`
+}
+
+func (baselineUserToken) TableName() string { return "user_token" }
+
+type baselineRole struct {
+	Id        uint `xorm:"pk autoincr"`
+	Name      string
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (baselineRole) TableName() string { return "role" }
+
+type baselineUserRole struct {
+	Id        uint      `xorm:"pk autoincr"`
+	UserId    uint      `xorm:"index"`
+	RoleId    uint      `xorm:"index"`
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (baselineUserRole) TableName() string { return "user_role" }
+
+type baselineLoginEvent struct {
+	Id         uint   `xorm:"pk autoincr"`
+	UserId     uint   `xorm:"index"`
+	LoginId    string `xorm:"index"`
+	Result     int32
+	RemoteAddr string
+	UserAgent  string
+	CreatedAt  time.Time `xorm:"created index"`
+}
+
+func (baselineLoginEvent) TableName() string { return "login_event" }
+
+type baselinePasswordHistory struct {
+	Id        uint `xorm:"pk autoincr"`
+	UserId    uint `xorm:"index"`
+	Password  string
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (baselinePasswordHistory) TableName() string { return "password_history" }
+
+type baselineUserMfa struct {
+	Id           uint `xorm:"pk autoincr"`
+	UserId       uint `xorm:"unique"`
+	Secret       string
+	Enabled      bool `xorm:"default false"`
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time `xorm:"created"`
+	UpdatedAt    time.Time `xorm:"updated"`
+}
+
+func (baselineUserMfa) TableName() string { return "user_mfa" }
+
+type baselineMfaRecoveryCode struct {
+	Id        uint   `xorm:"pk autoincr"`
+	UserId    uint   `xorm:"index"`
+	CodeHash  string `xorm:"index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (baselineMfaRecoveryCode) TableName() string { return "mfa_recovery_code" }
+
+type baselineOAuthClient struct {
+	Id           uint   `xorm:"pk autoincr"`
+	ClientId     string `xorm:"unique"`
+	ClientSecret string
+	Name         string
+	RedirectUris []string   `xorm:"text json"`
+	GrantTypes   []string   `xorm:"text json"`
+	Scopes       []string   `xorm:"text json"`
+	UserId       uint       `xorm:"index"`
+	CreatedAt    time.Time  `xorm:"created"`
+	UpdatedAt    time.Time  `xorm:"updated"`
+	DeletedAt    *time.Time `xorm:"deleted index"`
+}
+
+func (baselineOAuthClient) TableName() string { return "oauth_client" }
+
+type baselineOAuthCode struct {
+	Id                  uint   `xorm:"pk autoincr"`
+	CodeHash            string `xorm:"unique"`
+	ClientId            string `xorm:"index"`
+	UserId              uint   `xorm:"index"`
+	RedirectUri         string `xorm:"text"`
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string `xorm:"text"`
+	ExpiresAt           time.Time
+	UsedAt              *time.Time
+	CreatedAt           time.Time `xorm:"created"`
+}
+
+func (baselineOAuthCode) TableName() string { return "oauth_code" }
+
+type baselineOAuthToken struct {
+	Id               uint   `xorm:"pk autoincr"`
+	Jti              string `xorm:"unique"`
+	RefreshTokenHash string `xorm:"index"`
+	ClientId         string `xorm:"index"`
+	UserId           uint   `xorm:"index"`
+	Scope            string
+	ExpiresAt        time.Time
+	RefreshExpiresAt *time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time `xorm:"created"`
+}
+
+func (baselineOAuthToken) TableName() string { return "oauth_token" }
+
+type baselineUserIdentity struct {
+	Id          uint   `xorm:"pk autoincr"`
+	UserId      uint   `xorm:"index"`
+	Issuer      string `xorm:"unique(issuer_subject)"`
+	Subject     string `xorm:"unique(issuer_subject)"`
+	Email       string
+	LastLoginAt time.Time
+	CreatedAt   time.Time `xorm:"created"`
+}
+
+func (baselineUserIdentity) TableName() string { return "user_identity" }
@@ -0,0 +1,31 @@
+package migration
+
+import "time"
+
+// v002AppStatus App 상태와 사용 종료 예정 시각 추가, 기존 App은 active
+var v002AppStatus = Migration{
+	Version: 2,
+	Name:    "app_status",
+	Up: func(session *Session) error {
+		return session.Sync2(new(v002App))
+	},
+	Down: func(session *Session) error {
+		return session.dropColumns("app", new(baselineApp), "status", "sunset_at")
+	},
+}
+
+// Sync2는 struct에 없는 인덱스를 삭제하므로 기존 컬럼과 인덱스를 모두 포함
+type v002App struct {
+	Id        uint   `xorm:"pk"`
+	NameSpace string `xorm:"unique"`
+	IsDel     bool
+	FailOpen  bool   `xorm:"default false"`
+	Status    string `xorm:"varchar(16) notnull default 'active'"`
+	SunsetAt  *time.Time
+	Version   int        `xorm:"version"`
+	CreatedAt time.Time  `xorm:"created"`
+	UpdatedAt time.Time  `xorm:"updated"`
+	DeletedAt *time.Time `xorm:"deleted index"`
+}
+
+func (v002App) TableName() string { return "app" }
@@ -0,0 +1,26 @@
+package migration
+
+import "time"
+
+// v003TrafficArchive App 복구를 위해 삭제된 App의 Traffic을 보관하는 테이블 추가
+var v003TrafficArchive = Migration{
+	Version: 3,
+	Name:    "traffic_archive",
+	Up: func(session *Session) error {
+		return session.Sync2(new(v003TrafficArchiveTable))
+	},
+	Down: func(session *Session) error {
+		return session.DropTable(new(v003TrafficArchiveTable))
+	},
+}
+
+type v003TrafficArchiveTable struct {
+	Id        uint `xorm:"pk autoincr"`
+	AppId     uint `xorm:"index"`
+	Unit      string
+	Val       uint
+	Seq       uint
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (v003TrafficArchiveTable) TableName() string { return "traffic_archive" }
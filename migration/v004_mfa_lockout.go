@@ -0,0 +1,31 @@
+package migration
+
+import "time"
+
+// v004MfaLockout 회원별 2단계 인증 연속 실패 횟수와 잠금 종료 시각 추가
+var v004MfaLockout = Migration{
+	Version: 4,
+	Name:    "mfa_lockout",
+	Up: func(session *Session) error {
+		return session.Sync2(new(v004UserMfa))
+	},
+	Down: func(session *Session) error {
+		return session.dropColumns("user_mfa", new(baselineUserMfa), "failed_attempts", "locked_until")
+	},
+}
+
+// Sync2는 struct에 없는 인덱스를 삭제하므로 기존 컬럼과 인덱스를 모두 포함
+type v004UserMfa struct {
+	Id             uint `xorm:"pk autoincr"`
+	UserId         uint `xorm:"unique"`
+	Secret         string
+	Enabled        bool `xorm:"default false"`
+	LastUsedStep   int64
+	FailedAttempts int `xorm:"notnull default 0"`
+	LockedUntil    *time.Time
+	ConfirmedAt    *time.Time
+	CreatedAt      time.Time `xorm:"created"`
+	UpdatedAt      time.Time `xorm:"updated"`
+}
+
+func (v004UserMfa) TableName() string { return "user_mfa" }
@@ -0,0 +1,27 @@
+package migration
+
+import "time"
+
+// v005TokenUser API 토큰을 발급받은 회원 추가, 회원 탈퇴시 함께 폐기하기 위해 사용
+var v005TokenUser = Migration{
+	Version: 5,
+	Name:    "token_user",
+	Up: func(session *Session) error {
+		return session.Sync2(new(v005Token))
+	},
+	Down: func(session *Session) error {
+		return session.dropColumns("token", new(baselineToken), "user_id")
+	},
+}
+
+// Sync2는 struct에 없는 인덱스를 삭제하므로 기존 컬럼과 인덱스를 모두 포함
+type v005Token struct {
+	Id        uint   `xorm:"pk autoincr"`
+	UserId    uint   `xorm:"index"`
+	Token     string `xorm:"unique"`
+	IsDel     bool
+	CreatedAt time.Time `xorm:"created"`
+	DeletedAt *time.Time
+}
+
+func (v005Token) TableName() string { return "token" }
@@ -1,40 +1,95 @@
 package model
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/kekim-go/Author/constant"
 	"github.com/kekim-go/Author/database"
 	errors "github.com/kekim-go/Author/error"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"xorm.io/xorm"
 )
 
+// AppFilter : App 목록 조회 조건, NameSpace는 앞부분 일치 검색
+type AppFilter struct {
+	NameSpace      string
+	IncludeDeleted bool
+	Page           int
+	PerPage        int
+}
+
+// AppUsage : App별 사용 현황
+type AppUsage struct {
+	OperationCount uint // 삭제되지 않은 Operation 수
+	TokenCount     uint // App 사용이 허가된 API 키 중 폐기되지 않은 키 수
+}
+
 // App Api 서비스 관리 모델
 type App struct {
-	Id        uint       `xorm:"pk"`
-	NameSpace string     `xorm:"unique"`
-	IsDel     bool       `xorm:index default 0`
+	Id        uint   `xorm:"pk"`
+	NameSpace string `xorm:"unique"`
+	IsDel     bool
+	FailOpen  bool       `xorm:"default false"` // Redis 장애 시 트래픽 제한 없이 허용
+	Status    string     `xorm:"varchar(16) notnull default 'active'"`
+	SunsetAt  *time.Time // deprecated 상태의 사용 종료 예정 시각, 이후 API 호출 거부
 	Version   int        `xorm:"version"`
 	CreatedAt time.Time  `xorm:"created"`
 	UpdatedAt time.Time  `xorm:"updated"`
 	DeletedAt *time.Time `xorm:"deleted index"`
 
-	Operations []Operation `xorm:"- extends"`
-	Traffics   []Traffic   `xorm:"- extends"`
+	Operations []Operation `xorm:"- extends" json:"-"`
+	Traffics   []Traffic   `xorm:"- extends" json:"-"`
 }
 
 func (App) TableName() string {
 	return "app"
 }
 
+// 상태별로 변경할 수 있는 상태, deprecated는 종료 예정 시각만 바꿀 수 있도록 같은 상태로도 변경 가능
+var appStatusTransitions = map[string][]string{
+	constant.AppStatusDraft:      {constant.AppStatusActive},
+	constant.AppStatusActive:     {constant.AppStatusSuspended, constant.AppStatusDeprecated},
+	constant.AppStatusSuspended:  {constant.AppStatusActive, constant.AppStatusDeprecated},
+	constant.AppStatusDeprecated: {constant.AppStatusActive, constant.AppStatusSuspended, constant.AppStatusDeprecated},
+}
+
+// CurrentStatus 상태가 없는 경우(상태 추가 전에 캐시된 App) active로 처리
+func (a *App) CurrentStatus() string {
+	if len(a.Status) == 0 {
+		return constant.AppStatusActive
+	}
+
+	return a.Status
+}
+
+func (a *App) CanChangeStatus(status string) bool {
+	for _, next := range appStatusTransitions[a.CurrentStatus()] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsSunset deprecated 상태이고 종료 예정 시각이 지났으면 true
+func (a *App) IsSunset(now time.Time) bool {
+	return a.CurrentStatus() == constant.AppStatusDeprecated && a.SunsetAt != nil && !now.Before(*a.SunsetAt)
+}
+
 func (a *App) KeyName() string {
 	return constant.KeyApp + a.NameSpace
 }
 
-func (a *App) FindApp(orm *xorm.Engine) error {
-	found, err := orm.Get(a)
+func (a *App) FindApp(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Get(a)
 
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
@@ -47,23 +102,239 @@ func (a *App) FindApp(orm *xorm.Engine) error {
 	return nil
 }
 
-func (a *App) Delete(orm *xorm.Engine) error {
-	sql := "UPDATE app SET deleted_at = ?, is_del = 1 WHERE id = ?"
-	if _, err := orm.Exec(sql, time.Now(), a.Id); err != nil {
+// FindAppWithDeleted FindApp과 같으며 삭제된 App도 조회
+func (a *App) FindAppWithDeleted(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Unscoped().Get(a)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return errors.NewWithCode(http.StatusNotFound, "app not found")
+	}
+
+	return nil
+}
+
+// IsNameSpaceTaken 삭제되지 않은 다른 App이 같은 namespace를 사용 중이면 true
+func (a *App) IsNameSpaceTaken(ctx context.Context, orm xorm.Interface) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	taken, err := session.Where("name_space = ? AND id <> ?", a.NameSpace, a.Id).Exist(new(App))
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return taken, nil
+}
+
+func FindApps(ctx context.Context, orm xorm.Interface, filter AppFilter) ([]App, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = constant.DefaultPageSize
+	} else if filter.PerPage > constant.MaxPageSize {
+		filter.PerPage = constant.MaxPageSize
+	}
+
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if filter.IncludeDeleted {
+		session = session.Unscoped()
+	}
+	if len(filter.NameSpace) > 0 {
+		session = session.And("name_space LIKE ? ESCAPE '!'", likePrefix(filter.NameSpace))
+	}
+
+	apps := []App{}
+	total, err := session.Asc("id").Limit(filter.PerPage, (filter.Page-1)*filter.PerPage).FindAndCount(&apps)
+	if err != nil {
+		return nil, 0, errors.NewWithPrefix(err, "database error")
+	}
+
+	return apps, total, nil
+}
+
+// FindAppUsages App별 사용 현황 조회, 조회 결과가 없는 App은 0으로 채움
+func FindAppUsages(ctx context.Context, orm xorm.Interface, appIds []uint) (map[uint]AppUsage, error) {
+	usages := map[uint]AppUsage{}
+	if len(appIds) == 0 {
+		return usages, nil
+	}
+
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	type appCount struct {
+		AppId uint
+		Cnt   uint
+	}
+
+	var operationCounts []appCount
+	err := session.Table("operation").Select("app_id, COUNT(*) AS cnt").
+		In("app_id", appIds).And("deleted_at IS NULL").GroupBy("app_id").Find(&operationCounts)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	var tokenCounts []appCount
+	err = session.Table("app_token").Select("app_token.app_id, COUNT(*) AS cnt").
+		Join("INNER", "token", "token.id = app_token.token_id").
+		In("app_token.app_id", appIds).And("token.is_del = ?", false).GroupBy("app_token.app_id").Find(&tokenCounts)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	for _, appId := range appIds {
+		usages[appId] = AppUsage{}
+	}
+	for _, count := range operationCounts {
+		usage := usages[count.AppId]
+		usage.OperationCount = count.Cnt
+		usages[count.AppId] = usage
+	}
+	for _, count := range tokenCounts {
+		usage := usages[count.AppId]
+		usage.TokenCount = count.Cnt
+		usages[count.AppId] = usage
+	}
+
+	return usages, nil
+}
+
+// Update Version이 저장된 값과 같을 때만 수정하고 Version 1 증가, 다르면 409 오류
+func (a *App) Update(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	// 상태는 UpdateStatus로만 변경
+	affected, err := session.ID(a.Id).MustCols("fail_open").Omit("status", "sunset_at").Update(a)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected == 0 {
+		return errors.NewWithCode(http.StatusConflict, "app version conflict")
+	}
+
+	return nil
+}
+
+// UpdateStatus 상태와 종료 예정 시각 변경, Update와 같이 Version이 다르면 409 오류
+func (a *App) UpdateStatus(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	affected, err := session.ID(a.Id).Cols("status", "sunset_at").Update(a)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected == 0 {
+		return errors.NewWithCode(http.StatusConflict, "app version conflict")
+	}
+
+	return nil
+}
+
+func (a *App) Delete(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	sql := "UPDATE app SET deleted_at = ?, is_del = ? WHERE id = ?"
+	if _, err := session.Exec(sql, time.Now(), true, a.Id); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (a *App) DelRedis(rdb *database.RedisDB) {
-	rdb.Delete(a.KeyName())
+// Restore 삭제된 App 복구, 복구도 수정으로 보아 Version 1 증가
+func (a *App) Restore(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	sql := "UPDATE app SET deleted_at = NULL, is_del = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL"
+	result, err := session.Exec(sql, false, a.Id)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.NewWithCode(http.StatusConflict, "app is not deleted")
+	}
+	a.IsDel = false
+	a.DeletedAt = nil
+	a.Version++
+
+	return nil
+}
+
+// FindAppIdsDeletedBefore before 이전에 삭제된 App ID를 limit개까지 조회
+func FindAppIdsDeletedBefore(ctx context.Context, orm xorm.Interface, before time.Time, limit int) ([]uint, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	var appIds []uint
+	err := session.Table("app").Cols("id").Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Asc("id").Limit(limit).Find(&appIds)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return appIds, nil
+}
+
+// PurgeApps App 영구 삭제
+func PurgeApps(ctx context.Context, orm xorm.Interface, appIds ...uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Unscoped().In("id", appIds).Delete(&App{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// SetRedis App 정보를 JSON으로 저장 (Operation, Traffic 제외)
+func (a *App) SetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	return rdb.SetJSON(ctx, a.KeyName(), a, 0)
+}
+
+// GetRedis 저장된 App 정보 조회, database.IsMiss로 미적중 여부 확인
+func (a *App) GetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	cached := App{}
+	if err := rdb.GetJSON(ctx, a.KeyName(), &cached); err != nil {
+		return err
+	}
+	*a = cached
+
+	return nil
+}
+
+func (a *App) DelRedis(ctx context.Context, rdb *database.RedisDB) {
+	rdb.Delete(ctx, a.KeyName())
+}
+
+// AppStatusByGrpc proto enum 이름을 소문자로 바꾼 값을 상태로 사용 (ACTIVE -> active)
+func AppStatusByGrpc(status grpc_author.AppStatus) string {
+	return strings.ToLower(status.String())
 }
 
 func NewAppByGrpc(req *grpc_author.AppReq) *App {
 	app := &App{}
 	app.Id = uint(req.AppId)
 	app.NameSpace = req.NameSpace
+	app.FailOpen = req.FailPolicy == grpc_author.AppReq_FAIL_OPEN
+	app.Version = int(req.Version)
+	app.Status = AppStatusByGrpc(req.Status)
 
 	if len(req.Operations) > 0 {
 		for _, operation := range req.Operations {
@@ -71,6 +342,7 @@ func NewAppByGrpc(req *grpc_author.AppReq) *App {
 				Id:       uint(operation.OperationId),
 				AppId:    app.Id,
 				EndPoint: operation.EndPoint,
+				Version:  int(operation.Version),
 			})
 		}
 	}
@@ -88,3 +360,51 @@ func NewAppByGrpc(req *grpc_author.AppReq) *App {
 
 	return app
 }
+
+// GetGrpcInfo Operations, Traffics는 조회해 둔 경우에만 포함
+func (a *App) GetGrpcInfo() (*grpc_author.AppInfo, error) {
+	info := &grpc_author.AppInfo{
+		AppId:     uint32(a.Id),
+		NameSpace: a.NameSpace,
+		Version:   uint32(a.Version),
+	}
+	if a.FailOpen {
+		info.FailPolicy = grpc_author.AppReq_FAIL_OPEN
+	}
+	info.Status = grpc_author.AppStatus(grpc_author.AppStatus_value[strings.ToUpper(a.CurrentStatus())])
+
+	var err error
+	if info.CreatedAt, err = ptypes.TimestampProto(a.CreatedAt); err != nil {
+		return nil, err
+	}
+	if info.UpdatedAt, err = ptypes.TimestampProto(a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if a.SunsetAt != nil {
+		if info.SunsetAt, err = ptypes.TimestampProto(*a.SunsetAt); err != nil {
+			return nil, err
+		}
+	}
+	if a.DeletedAt != nil {
+		if info.DeletedAt, err = ptypes.TimestampProto(*a.DeletedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, operation := range a.Operations {
+		info.Operations = append(info.Operations, &grpc_author.AppReq_Operation{
+			OperationId: uint32(operation.Id),
+			EndPoint:    operation.EndPoint,
+			Version:     uint32(operation.Version),
+		})
+	}
+	for _, traffic := range a.Traffics {
+		info.Traffics = append(info.Traffics, &grpc_author.AppReq_AppTraffic{
+			Unit:  traffic.Unit,
+			Value: uint32(traffic.Val),
+			Seq:   uint32(traffic.Seq),
+		})
+	}
+
+	return info, nil
+}
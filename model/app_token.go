@@ -1,11 +1,13 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
 	errors "github.com/kekim-go/Author/error"
 	"xorm.io/xorm"
 )
@@ -17,12 +19,15 @@ type AppToken struct {
 	TokenId   uint      `xorm:"index"`
 	CreatedAt time.Time `xorm:"created"`
 
-	App   App   `xorm:"- extends"`
-	Token Token `xorm:"- extends"`
+	App   App   `xorm:"- extends" json:"-"`
+	Token Token `xorm:"- extends" json:"-"`
 }
 
-func (at *AppToken) FindOne(orm *xorm.Engine) error {
-	found, err := orm.Get(at)
+func (at *AppToken) FindOne(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Get(at)
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
 	}
@@ -38,8 +43,27 @@ func (at *AppToken) KeyName() string {
 	return fmt.Sprintf("%s%d:%d", constant.KeyAuth, at.TokenId, at.AppId)
 }
 
-func (at *AppToken) FindByAppAndToken(orm *xorm.Engine) error {
-	found, err := orm.Get(at)
+// SetRedis App-Token 정보를 JSON으로 저장, constant.AppTokenCacheExpInterval 동안 유지
+func (at *AppToken) SetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	return rdb.SetJSON(ctx, at.KeyName(), at, constant.AppTokenCacheExpInterval)
+}
+
+// GetRedis 저장된 App-Token 정보 조회, database.IsMiss로 미적중 여부 확인
+func (at *AppToken) GetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	cached := AppToken{}
+	if err := rdb.GetJSON(ctx, at.KeyName(), &cached); err != nil {
+		return err
+	}
+	*at = cached
+
+	return nil
+}
+
+func (at *AppToken) FindByAppAndToken(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Get(at)
 
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
@@ -51,3 +75,14 @@ func (at *AppToken) FindByAppAndToken(orm *xorm.Engine) error {
 
 	return nil
 }
+
+func DeleteAppTokensByApps(ctx context.Context, orm xorm.Interface, appIds ...uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.In("app_id", appIds).Delete(&AppToken{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"xorm.io/xorm"
+)
+
+const DefaultLoginHistoryLimit = 20
+const MaxLoginHistoryLimit = 100
+
+// LoginEvent : 로그인 시도 이력 관리 모델
+type LoginEvent struct {
+	Id         uint   `xorm:"pk autoincr"`
+	UserId     uint   `xorm:"index"`
+	LoginId    string `xorm:"index"`
+	Result     int32
+	RemoteAddr string
+	UserAgent  string
+	CreatedAt  time.Time `xorm:"created index"`
+}
+
+func (LoginEvent) TableName() string {
+	return "login_event"
+}
+
+func (e *LoginEvent) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Insert(e); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+func (e *LoginEvent) GetGrpcEvent() (*grpc_author.LoginEvent, error) {
+	createdAt, err := ptypes.TimestampProto(e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpc_author.LoginEvent{
+		Id:         uint32(e.Id),
+		LoginId:    e.LoginId,
+		Result:     grpc_author.AuthResult(e.Result),
+		RemoteAddr: e.RemoteAddr,
+		UserAgent:  e.UserAgent,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// 회원의 최근 로그인 이력을 최신순으로 조회
+func FindLoginEventsByUser(ctx context.Context, orm xorm.Interface, userId uint, limit int) ([]LoginEvent, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = DefaultLoginHistoryLimit
+	} else if limit > MaxLoginHistoryLimit {
+		limit = MaxLoginHistoryLimit
+	}
+
+	events := []LoginEvent{}
+	err := session.Where("user_id = ?", userId).Desc("id").Limit(limit).Find(&events)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFindLoginEventsByUser(t *testing.T) {
+	orm := newTestOrm(t, new(LoginEvent))
+	background := context.Background()
+
+	for i := 0; i < MaxLoginHistoryLimit+5; i++ {
+		event := &LoginEvent{UserId: 1, LoginId: "alice", Result: int32(i)}
+		if err := event.Save(background, orm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := (&LoginEvent{UserId: 2, LoginId: "bob"}).Save(background, orm); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{0, DefaultLoginHistoryLimit},
+		{3, 3},
+		{MaxLoginHistoryLimit + 1, MaxLoginHistoryLimit},
+	}
+	for _, test := range tests {
+		events, err := FindLoginEventsByUser(background, orm, 1, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != test.want {
+			t.Errorf("limit %d: events = %d, want %d", test.limit, len(events), test.want)
+			continue
+		}
+		// 최신순
+		if events[0].Result != MaxLoginHistoryLimit+4 || events[1].Result != MaxLoginHistoryLimit+3 {
+			t.Errorf("limit %d: first events = %+v, %+v", test.limit, events[0], events[1])
+		}
+		for _, event := range events {
+			if event.UserId != 1 {
+				t.Errorf("event of other user = %+v", event)
+			}
+		}
+	}
+}
+
+func TestLoginEventGetGrpcEvent(t *testing.T) {
+	orm := newTestOrm(t, new(LoginEvent))
+	event := &LoginEvent{UserId: 1, LoginId: "alice", Result: 2, RemoteAddr: "127.0.0.1:1234", UserAgent: "test"}
+	if err := event.Save(context.Background(), orm); err != nil {
+		t.Fatal(err)
+	}
+
+	grpcEvent, err := event.GetGrpcEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grpcEvent.Id != uint32(event.Id) || grpcEvent.LoginId != "alice" || int32(grpcEvent.Result) != 2 ||
+		grpcEvent.RemoteAddr != "127.0.0.1:1234" || grpcEvent.UserAgent != "test" || grpcEvent.CreatedAt.AsTime().Unix() != event.CreatedAt.Unix() {
+		t.Errorf("grpc event = %+v", grpcEvent)
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// MfaRecoveryCode : 인증 앱 분실시 사용하는 1회용 복구 코드, 원문은 발급시에만 노출하고 해시만 저장
+type MfaRecoveryCode struct {
+	Id        uint   `xorm:"pk autoincr"`
+	UserId    uint   `xorm:"index"`
+	CodeHash  string `xorm:"index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (MfaRecoveryCode) TableName() string {
+	return "mfa_recovery_code"
+}
+
+// 입력 형식(대소문자, '-' 구분자)에 관계없이 동일한 해시 생성
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(normalized)))
+}
+
+// 기존 복구 코드를 모두 폐기하고 새 코드로 교체
+func ReplaceRecoveryCodes(ctx context.Context, orm xorm.Interface, userId uint, codes []string) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Where("user_id = ?", userId).Delete(&MfaRecoveryCode{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	var recoveryCodes []MfaRecoveryCode
+	for _, code := range codes {
+		recoveryCodes = append(recoveryCodes, MfaRecoveryCode{UserId: userId, CodeHash: HashRecoveryCode(code)})
+	}
+
+	if _, err := session.Insert(&recoveryCodes); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// 미사용 복구 코드와 일치하면 사용 처리 후 true 반환
+func UseRecoveryCode(ctx context.Context, orm xorm.Interface, userId uint, code string) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	now := time.Now()
+	affected, err := session.Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, HashRecoveryCode(code)).
+		Cols("used_at").Update(&MfaRecoveryCode{UsedAt: &now})
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return affected > 0, nil
+}
+
+func DeleteRecoveryCodes(ctx context.Context, orm xorm.Interface, userId uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Where("user_id = ?", userId).Delete(&MfaRecoveryCode{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
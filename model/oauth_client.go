@@ -0,0 +1,113 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+const GrantClientCredentials = "client_credentials"
+const GrantAuthorizationCode = "authorization_code"
+const GrantRefreshToken = "refresh_token"
+
+const ScopeOpenId = "openid"
+const ScopeProfile = "profile"
+const ScopeEmail = "email"
+
+// OAuthClient : OAuth2 클라이언트 등록 정보
+// 비밀키가 없는 public 클라이언트는 authorization_code(PKCE)만 사용 가능
+type OAuthClient struct {
+	Id           uint   `xorm:"pk autoincr"`
+	ClientId     string `xorm:"unique"`
+	ClientSecret string // PasswordHasher로 해시한 값, public 클라이언트는 빈 값
+	Name         string
+	RedirectUris []string   `xorm:"text json"`
+	GrantTypes   []string   `xorm:"text json"`
+	Scopes       []string   `xorm:"text json"`
+	UserId       uint       `xorm:"index"` // 등록한 관리자
+	CreatedAt    time.Time  `xorm:"created"`
+	UpdatedAt    time.Time  `xorm:"updated"`
+	DeletedAt    *time.Time `xorm:"deleted index"`
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_client"
+}
+
+func (c *OAuthClient) IsConfidential() bool {
+	return len(c.ClientSecret) > 0
+}
+
+func (c *OAuthClient) FindByClientId(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if len(c.ClientId) == 0 {
+		return errors.NewWithCode(http.StatusNotFound, "oauth client not found")
+	}
+
+	found, err := session.Where("client_id = ?", c.ClientId).Get(c)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return errors.NewWithCode(http.StatusNotFound, "oauth client not found")
+	}
+
+	return nil
+}
+
+func (c *OAuthClient) Delete(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.ID(c.Id).Delete(&OAuthClient{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+func (c *OAuthClient) HasGrantType(grantType string) bool {
+	return containsString(c.GrantTypes, grantType)
+}
+
+func (c *OAuthClient) HasRedirectUri(redirectUri string) bool {
+	return containsString(c.RedirectUris, redirectUri)
+}
+
+// AllowedScope 요청 scope가 클라이언트에 허용된 범위인지 확인, 요청이 없으면 허용된 전체 scope 반환
+func (c *OAuthClient) AllowedScope(scope string) (string, bool) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return strings.Join(c.Scopes, " "), true
+	}
+
+	for _, s := range requested {
+		if !containsString(c.Scopes, s) {
+			return "", false
+		}
+	}
+
+	return strings.Join(requested, " "), true
+}
+
+// 공백으로 구분된 scope 문자열에 해당 scope가 포함되어 있는지 확인
+func HasScope(scope string, s string) bool {
+	return containsString(strings.Fields(scope), s)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
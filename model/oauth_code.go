@@ -0,0 +1,77 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// OAuthCode : authorization_code 발급 내역, 코드 원문은 저장하지 않고 해시만 보관
+type OAuthCode struct {
+	Id                  uint   `xorm:"pk autoincr"`
+	CodeHash            string `xorm:"unique"`
+	ClientId            string `xorm:"index"`
+	UserId              uint   `xorm:"index"`
+	RedirectUri         string `xorm:"text"`
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string `xorm:"text"`
+	ExpiresAt           time.Time
+	UsedAt              *time.Time
+	CreatedAt           time.Time `xorm:"created"`
+}
+
+func (OAuthCode) TableName() string {
+	return "oauth_code"
+}
+
+func HashOAuthSecret(secret string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(secret)))
+}
+
+func (c *OAuthCode) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Insert(c); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// UseOAuthCode 미사용 코드를 사용 처리하고 반환, 코드는 한 번만 교환 가능
+// 발급받은 클라이언트와 redirect_uri가 일치하고 만료되지 않은 경우에만 사용 처리하여
+// 다른 클라이언트가 가로챈 코드로 요청해도 정상 교환을 막지 못하도록 함
+func UseOAuthCode(ctx context.Context, orm xorm.Interface, code string, clientId string, redirectUri string) (*OAuthCode, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	c := &OAuthCode{}
+	found, err := session.Where("code_hash = ?", HashOAuthSecret(code)).Get(c)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found || c.ClientId != clientId || c.RedirectUri != redirectUri || time.Now().After(c.ExpiresAt) {
+		return nil, errors.NewWithCode(http.StatusNotFound, "authorization code not found")
+	}
+
+	now := time.Now()
+	affected, err := session.Where("id = ? AND used_at IS NULL", c.Id).Cols("used_at").Update(&OAuthCode{UsedAt: &now})
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected == 0 {
+		return nil, errors.NewWithCode(http.StatusConflict, "authorization code already used")
+	}
+
+	return c, nil
+}
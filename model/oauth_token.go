@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// OAuthToken : OAuth2로 발급한 access/refresh token 내역 (introspection, revocation 처리용)
+// access token은 JWT의 jti로, refresh token은 해시로 조회
+type OAuthToken struct {
+	Id               uint   `xorm:"pk autoincr"`
+	Jti              string `xorm:"unique"`
+	RefreshTokenHash string `xorm:"index"`
+	ClientId         string `xorm:"index"`
+	UserId           uint   `xorm:"index"`
+	Scope            string
+	ExpiresAt        time.Time
+	RefreshExpiresAt *time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time `xorm:"created"`
+}
+
+func (OAuthToken) TableName() string {
+	return "oauth_token"
+}
+
+func (t *OAuthToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t *OAuthToken) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Insert(t); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// Revoke 폐기되지 않은 경우에만 폐기, 이미 폐기되었으면 409 오류 (동시에 같은 refresh token 사용 방지)
+func (t *OAuthToken) Revoke(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	now := time.Now()
+	affected, err := session.Where("id = ? AND revoked_at IS NULL", t.Id).Cols("revoked_at").Update(&OAuthToken{RevokedAt: &now})
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected == 0 {
+		return errors.NewWithCode(http.StatusConflict, "oauth token already revoked")
+	}
+	t.RevokedAt = &now
+
+	return nil
+}
+
+func FindOAuthTokenByJti(ctx context.Context, orm xorm.Interface, jti string) (*OAuthToken, error) {
+	return findOAuthToken(ctx, orm, "jti = ?", jti)
+}
+
+func FindOAuthTokenByRefreshToken(ctx context.Context, orm xorm.Interface, refreshToken string) (*OAuthToken, error) {
+	return findOAuthToken(ctx, orm, "refresh_token_hash = ?", HashOAuthSecret(refreshToken))
+}
+
+func findOAuthToken(ctx context.Context, orm xorm.Interface, query string, arg string) (*OAuthToken, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if len(arg) == 0 {
+		return nil, errors.NewWithCode(http.StatusNotFound, "oauth token not found")
+	}
+
+	t := &OAuthToken{}
+	found, err := session.Where(query, arg).Get(t)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return nil, errors.NewWithCode(http.StatusNotFound, "oauth token not found")
+	}
+
+	return t, nil
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -14,13 +16,13 @@ type Operation struct {
 	Id        uint `xorm:"pk"`
 	AppId     uint `xorm:"index"`
 	EndPoint  string
-	IsDel     bool       `xorm:index default 0`
+	IsDel     bool
 	Version   int        `xorm:"version"`
 	CreatedAt time.Time  `xorm:"created"`
 	UpdatedAt time.Time  `xorm:"updated"`
 	DeletedAt *time.Time `xorm:"deleted index"`
 
-	App App `xorm:"- extends"`
+	App App `xorm:"- extends" json:"-"`
 }
 
 func (Operation) TableName() string {
@@ -31,8 +33,11 @@ func (o *Operation) KeyName() string {
 	return constant.KeyOperation + o.EndPoint
 }
 
-func (o *Operation) FindOperation(orm *xorm.Engine) error {
-	found, err := orm.Get(o)
+func (o *Operation) FindOperation(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Get(o)
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
 	}
@@ -44,35 +49,66 @@ func (o *Operation) FindOperation(orm *xorm.Engine) error {
 	return nil
 }
 
-func (o *Operation) SetRedis(rdb *database.RedisDB) {
-	rdb.Set(o.KeyName(), o.Id)
+// SetRedis Operation 정보를 JSON으로 저장
+func (o *Operation) SetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	return rdb.SetJSON(ctx, o.KeyName(), o, 0)
+}
+
+// GetRedis 저장된 Operation 정보 조회, 다른 App의 같은 EndPoint로 저장된 경우 미적중으로 처리
+func (o *Operation) GetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	cached := Operation{}
+	if err := rdb.GetJSON(ctx, o.KeyName(), &cached); err != nil {
+		return err
+	}
+	if cached.AppId != o.AppId {
+		return fmt.Errorf("%w: %s: app id mismatch", database.ErrInvalidValue, o.KeyName())
+	}
+	cached.App = o.App
+	*o = cached
+
+	return nil
 }
 
-func (o *Operation) DelRedis(rdb *database.RedisDB) {
-	rdb.Delete(o.KeyName())
+func (o *Operation) DelRedis(ctx context.Context, rdb *database.RedisDB) {
+	rdb.Delete(ctx, o.KeyName())
 }
 
-func (o *Operation) Update(orm *xorm.Engine) error {
-	if _, err := orm.ID(o.Id).Update(o); err != nil {
+// Update Version이 저장된 값과 같을 때만 수정하고 Version 1 증가, 다르면 409 오류
+func (o *Operation) Update(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	affected, err := session.ID(o.Id).Update(o)
+	if err != nil {
 		return err
 	}
 
+	if affected == 0 {
+		return errors.NewWithCode(http.StatusConflict, "operation version conflict")
+	}
+
 	return nil
 }
 
-func (o *Operation) Delete(orm *xorm.Engine) error {
+func (o *Operation) Delete(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
 	sql := "UPDATE operation SET deleted_at = ? WHERE id = ?"
-	if _, err := orm.Exec(sql, time.Now(), o.Id); err != nil {
+	if _, err := session.Exec(sql, time.Now(), o.Id); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func FindOperationsByApp(orm *xorm.Engine, appId uint) ([]Operation, error) {
+func FindOperationsByApp(ctx context.Context, orm xorm.Interface, appId uint) ([]Operation, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
 	operations := []Operation{}
 
-	err := orm.Where("app_id = ?", appId).Find(&operations)
+	err := session.Where("app_id = ?", appId).Find(&operations)
 
 	if err != nil {
 		return nil, errors.New("database error; " + err.Error())
@@ -80,3 +116,35 @@ func FindOperationsByApp(orm *xorm.Engine, appId uint) ([]Operation, error) {
 
 	return operations, nil
 }
+
+// RestoreOperationsByApp App 삭제시 함께 삭제(is_del)된 Operation 복구
+// App 수정 중 삭제된 Operation은 is_del이 false이므로 복구하지 않음
+func RestoreOperationsByApp(ctx context.Context, orm xorm.Interface, appId uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	sql := "UPDATE operation SET deleted_at = NULL, is_del = ? WHERE app_id = ? AND is_del = ?"
+	if _, err := session.Exec(sql, false, appId, true); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// PurgeOperations 지정한 App의 Operation과 before 이전에 삭제된 Operation 영구 삭제
+func PurgeOperations(ctx context.Context, orm xorm.Interface, before time.Time, appIds ...uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if len(appIds) > 0 {
+		if _, err := session.Unscoped().In("app_id", appIds).Delete(&Operation{}); err != nil {
+			return errors.NewWithPrefix(err, "database error")
+		}
+	}
+
+	if _, err := session.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&Operation{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
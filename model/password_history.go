@@ -0,0 +1,47 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// PasswordHistory : 비밀번호 재사용 방지를 위한 회원별 비밀번호 해시 이력
+type PasswordHistory struct {
+	Id        uint `xorm:"pk autoincr"`
+	UserId    uint `xorm:"index"`
+	Password  string
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
+
+func (ph *PasswordHistory) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Insert(ph); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// 회원의 최근 비밀번호 이력을 최신순으로 조회
+func FindRecentPasswordHistories(ctx context.Context, orm xorm.Interface, userId uint, limit int) ([]PasswordHistory, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	histories := []PasswordHistory{}
+
+	err := session.Where("user_id = ?", userId).Desc("id").Limit(limit).Find(&histories)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return histories, nil
+}
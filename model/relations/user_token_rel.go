@@ -1,6 +1,7 @@
 package relations
 
 import (
+	"context"
 	"net/http"
 
 	errors "github.com/kekim-go/Author/error"
@@ -10,16 +11,20 @@ import (
 
 // XORM 처리를 위한 별도 struct 구성
 // join 쿼리 수행시 struct에 명시된 순서 주의 필요함 (https://gobook.io/read/gitea.com/xorm/manual-en-US/chapter-05/5.join.html)
+// user는 PostgreSQL 예약어이므로 조건절에서 `user`로 표기 (xorm이 DB별 식별자 따옴표로 변환)
 type UserTokenRel struct {
 	User  model.User      `xorm:"extends"`
 	Token model.UserToken `xorm:"extends"`
 }
 
-func (ut *UserTokenRel) FindByUserLoginId(orm *xorm.Engine) error {
-	found, err := orm.Table("user").Join(
+func (ut *UserTokenRel) FindByUserLoginId(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := model.Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Table("user").Join(
 		"LEFT OUTER", "user_token",
-		"user.id = user_token.user_id",
-	).Where("user.login_id = ?", ut.User.LoginId).Get(ut)
+		"`user`.id = user_token.user_id",
+	).Where("`user`.login_id = ? AND `user`.deleted_at IS NULL", ut.User.LoginId).Get(ut)
 
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
@@ -32,12 +37,15 @@ func (ut *UserTokenRel) FindByUserLoginId(orm *xorm.Engine) error {
 	return nil
 }
 
-func (ut *UserTokenRel) FindByRefreshToken(orm *xorm.Engine) error {
+func (ut *UserTokenRel) FindByRefreshToken(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := model.Session(ctx, orm)
+	defer cancel()
+
 	var utr UserTokenRel
-	found, err := orm.Table("user").Join(
+	found, err := session.Table("user").Join(
 		"INNER", "user_token",
-		"user.id = user_token.user_id",
-	).Where("user_token.refresh_token = ?", ut.Token.RefreshToken).Get(&utr)
+		"`user`.id = user_token.user_id",
+	).Where("user_token.refresh_token = ? AND `user`.deleted_at IS NULL", ut.Token.RefreshToken).Get(&utr)
 	*ut = utr
 
 	if err != nil {
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+var queryTimeout time.Duration
+
+// LIKE 검색어의 와일드카드 이스케이프, DB마다 문자열 리터럴의 \ 처리가 달라 ESCAPE 문자로 ! 사용
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// SetQueryTimeout 모델 함수별 DB 호출 제한 시간 설정, 0이면 요청 context의 deadline만 적용
+func SetQueryTimeout(timeout time.Duration) {
+	queryTimeout = timeout
+}
+
+// Session 요청 context에 DB 호출 제한 시간을 적용한 세션과 해제 함수 반환
+// 트랜잭션 세션은 트랜잭션을 시작할 때 지정한 context를 그대로 사용
+func Session(ctx context.Context, orm xorm.Interface) (*xorm.Session, context.CancelFunc) {
+	if session, ok := orm.(*xorm.Session); ok {
+		return session, func() {}
+	}
+
+	var cancel context.CancelFunc
+	if queryTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, queryTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	return orm.(interface {
+		Context(context.Context) *xorm.Session
+	}).Context(ctx), cancel
+}
+
+// likePrefix 앞부분 일치 검색 값, 검색어의 %, _는 문자 그대로 비교 ("LIKE ? ESCAPE '!'"와 함께 사용)
+func likePrefix(value string) string {
+	return likeEscaper.Replace(value) + "%"
+}
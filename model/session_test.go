@@ -0,0 +1,155 @@
+package model
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"xorm.io/xorm"
+)
+
+func newTestOrm(t *testing.T, beans ...interface{}) *xorm.Engine {
+	t.Helper()
+
+	orm, err := xorm.NewEngine("sqlite3", filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { orm.Close() })
+
+	if err := orm.Sync2(beans...); err != nil {
+		t.Fatal(err)
+	}
+
+	return orm
+}
+
+// 요청 context가 취소되었거나 제한 시간이 지나면 DB 호출 실패
+func TestSessionContext(t *testing.T) {
+	orm := newTestOrm(t, new(App))
+	if _, err := orm.Insert(&App{Id: 1, NameSpace: "svc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := FindApps(context.Background(), orm, AppFilter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := FindApps(canceled, orm, AppFilter{}); err == nil {
+		t.Error("query succeeded with canceled context")
+	}
+
+	SetQueryTimeout(time.Nanosecond)
+	defer SetQueryTimeout(0)
+	if _, _, err := FindApps(context.Background(), orm, AppFilter{}); err == nil {
+		t.Error("query succeeded after query timeout")
+	}
+
+	// 트랜잭션 세션은 제한 시간을 적용하지 않고 그대로 사용
+	session := orm.NewSession()
+	defer session.Close()
+	if got, _ := Session(context.Background(), session); got != session {
+		t.Error("transaction session replaced")
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	tests := map[string]string{
+		"svc":     "svc%",
+		"svc_":    "svc!_%",
+		"100%":    "100!%%",
+		"a!b":     "a!!b%",
+		`dir\sub`: `dir\sub%`,
+	}
+	for value, want := range tests {
+		if got := likePrefix(value); got != want {
+			t.Errorf("likePrefix(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+// 검색어의 %, _, \ 는 와일드카드가 아닌 문자로 비교해야 함
+func TestFindAppsNameSpaceWildcard(t *testing.T) {
+	orm := newTestOrm(t, new(App))
+	for i, nameSpace := range []string{"svc_a", "svcXa", "svc%b", `svc\c`, "other"} {
+		if _, err := orm.Insert(&App{Id: uint(i + 1), NameSpace: nameSpace}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string][]string{
+		"svc":   {"svc_a", "svcXa", "svc%b", `svc\c`},
+		"svc_":  {"svc_a"},
+		"svc%":  {"svc%b"},
+		`svc\`:  {`svc\c`},
+		"%":     nil,
+		"other": {"other"},
+	}
+	for nameSpace, want := range tests {
+		apps, total, err := FindApps(context.Background(), orm, AppFilter{NameSpace: nameSpace})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, app := range apps {
+			got = append(got, app.NameSpace)
+		}
+		if int(total) != len(want) || !equalStrings(got, want) {
+			t.Errorf("FindApps(%q) = %v (total %d), want %v", nameSpace, got, total, want)
+		}
+	}
+}
+
+func TestFindUsersWildcard(t *testing.T) {
+	orm := newTestOrm(t, new(User))
+	users := []User{
+		{LoginId: "user_1", Email: "a_b@example.com", Name: "50% off"},
+		{LoginId: "userX1", Email: "aXb@example.com", Name: "500 off"},
+	}
+	for i := range users {
+		if _, err := orm.Insert(&users[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		filter UserFilter
+		want   []string
+	}{
+		{UserFilter{LoginId: "user"}, []string{"user_1", "userX1"}},
+		{UserFilter{LoginId: "user_"}, []string{"user_1"}},
+		{UserFilter{Email: "a_"}, []string{"user_1"}},
+		{UserFilter{Name: "50%"}, []string{"user_1"}},
+		{UserFilter{Name: "%"}, nil},
+	}
+	for _, test := range tests {
+		found, total, err := FindUsers(context.Background(), orm, test.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, user := range found {
+			got = append(got, user.LoginId)
+		}
+		if int(total) != len(test.want) || !equalStrings(got, test.want) {
+			t.Errorf("FindUsers(%+v) = %v (total %d), want %v", test.filter, got, total, test.want)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
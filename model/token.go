@@ -1,19 +1,22 @@
 package model
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
 	errors "github.com/kekim-go/Author/error"
 	"xorm.io/xorm"
 )
 
 // Token : API 인증 토큰 관리 모델
 type Token struct {
-	Id    uint   `xorm:"pk autoincr"`
-	Token string `xorm:"unique"`
-	IsDel bool   `xorm:index default 0`
+	Id     uint   `xorm:"pk autoincr"`
+	UserId uint   `xorm:"index"` // 발급 회원, 토큰을 발급하는 서비스에서 기록하며 회원 탈퇴시 함께 폐기
+	Token  string `xorm:"unique"`
+	IsDel  bool
 
 	CreatedAt time.Time `xorm:"created"`
 	DeletedAt *time.Time
@@ -23,8 +26,11 @@ func (t *Token) KeyName() string {
 	return constant.KeyToken + t.Token
 }
 
-func (t *Token) FindByToken(orm *xorm.Engine) error {
-	found, err := orm.Get(t)
+func (t *Token) FindByToken(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Where("is_del = ?", false).Get(t)
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
 	}
@@ -35,3 +41,47 @@ func (t *Token) FindByToken(orm *xorm.Engine) error {
 
 	return nil
 }
+
+// SetRedis Token 정보를 JSON으로 저장
+func (t *Token) SetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	return rdb.SetJSON(ctx, t.KeyName(), t, 0)
+}
+
+// GetRedis 저장된 Token 정보 조회, database.IsMiss로 미적중 여부 확인
+func (t *Token) GetRedis(ctx context.Context, rdb *database.RedisDB) error {
+	cached := Token{}
+	if err := rdb.GetJSON(ctx, t.KeyName(), &cached); err != nil {
+		return err
+	}
+	*t = cached
+
+	return nil
+}
+
+func (t *Token) DelRedis(ctx context.Context, rdb *database.RedisDB) {
+	rdb.Delete(ctx, t.KeyName())
+}
+
+// RevokeTokensByUser 회원에게 발급된 API 토큰을 모두 폐기 처리하고 폐기된 토큰 목록 반환 (캐시 삭제용)
+func RevokeTokensByUser(ctx context.Context, orm xorm.Interface, userId uint) ([]Token, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	tokens := []Token{}
+	if err := session.Where("user_id = ? AND is_del = ?", userId, false).Find(&tokens); err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if len(tokens) == 0 {
+		return tokens, nil
+	}
+
+	now := time.Now()
+	_, err := session.Where("user_id = ? AND is_del = ?", userId, false).Cols("is_del", "deleted_at").
+		Update(&Token{IsDel: true, DeletedAt: &now})
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return tokens, nil
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/kekim-go/Author/constant"
 	"github.com/kekim-go/Author/database"
 	errors "github.com/kekim-go/Author/error"
@@ -20,17 +23,179 @@ type Traffic struct {
 	UpdatedAt time.Time `xorm:"updated"`
 	DeletedAt *time.Time
 
-	App App `xorm:"- extends"`
+	App App `xorm:"- extends" json:"-"`
 }
 
 func (t *Traffic) KeyName() string {
 	return fmt.Sprintf("%s%d:%s", constant.KeyAppTrafficPrefix, t.AppId, t.Unit)
 }
 
-func FindTrafficsByApp(orm *xorm.Engine, appId uint) ([]Traffic, error) {
+// TokenTrafficKey Tf:{TokenId:AppId}:{unit}
+// cluster 모드에서 같은 키-앱의 카운터를 한 스크립트에서 다룰 수 있도록 {TokenId:AppId}를 hash tag로 사용
+func TokenTrafficKey(tokenId uint, appId uint, unit string) string {
+	return fmt.Sprintf("%s{%d:%d}:%s", constant.KeyTrafficPrefix, tokenId, appId, unit)
+}
+
+// TokenTrafficDetailKey TfD:{TokenId:AppId}:{OperationId}:{unit}, TokenTrafficKey와 같은 hash tag 사용
+func TokenTrafficDetailKey(tokenId uint, appId uint, operationId uint, unit string) string {
+	return fmt.Sprintf("%s{%d:%d}:%d:%s", constant.KeyTrafficDetailPrefix, tokenId, appId, operationId, unit)
+}
+
+// ParseTokenTrafficKey TokenTrafficKey에서 키, 앱 ID와 단위 추출
+func ParseTokenTrafficKey(key string) (tokenId uint, appId uint, unit string, err error) {
+	if _, err = fmt.Sscanf(strings.TrimPrefix(key, constant.KeyTrafficPrefix), "{%d:%d}:%s", &tokenId, &appId, &unit); err != nil {
+		return 0, 0, "", errors.New("invalid traffic key: " + key)
+	}
+
+	return tokenId, appId, unit, nil
+}
+
+// TrafficCount : 단위별 허용치와 이번 호출을 포함한 사용량, 허용치를 초과한 단위는 Allowed가 false
+type TrafficCount struct {
+	Unit    string
+	Limit   uint
+	Used    uint
+	Allowed bool
+}
+
+// KEYS: 단위별 키-앱 카운터, operation 카운터 쌍 / ARGV: 단위별 허용치
+// 허용치 미만인 단위만 두 카운터를 증가시키고, 단위별 {사용량, 허용 여부(1/0)} 반환
+var incrTokenTrafficScript = redis.NewScript(`
+local result = {}
+for i = 1, #ARGV do
+	local key = KEYS[i * 2 - 1]
+	local used = tonumber(redis.call('GET', key)) or 0
+	if used < tonumber(ARGV[i]) then
+		used = redis.call('INCR', key)
+		redis.call('INCR', KEYS[i * 2])
+		result[#result + 1] = used
+		result[#result + 1] = 1
+	else
+		result[#result + 1] = used
+		result[#result + 1] = 0
+	end
+end
+return result
+`)
+
+// IncrTokenTraffic counts의 Unit, Limit 기준으로 키-앱, 키-앱-operation 카운터를 확인하고 증가
+// 조회와 증가를 한 스크립트로 실행하여 동시 요청이 허용치를 넘지 않도록 함
+// 카운터 키가 같은 hash tag를 사용하므로 cluster 모드에서도 한 슬롯에서 실행됨
+func IncrTokenTraffic(ctx context.Context, rdb *database.RedisDB, tokenId uint, appId uint, operationId uint, counts []TrafficCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(counts)*2)
+	args := make([]interface{}, 0, len(counts))
+	for _, count := range counts {
+		keys = append(keys, TokenTrafficKey(tokenId, appId, count.Unit), TokenTrafficDetailKey(tokenId, appId, operationId, count.Unit))
+		args = append(args, count.Limit)
+	}
+
+	result, err := rdb.RunScript(ctx, incrTokenTrafficScript, keys, args...)
+	if err != nil {
+		return err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != len(counts)*2 {
+		return fmt.Errorf("%w: unexpected traffic script result: %v", database.ErrInvalidValue, result)
+	}
+	for i := range counts {
+		used, _ := values[i*2].(int64)
+		allowed, _ := values[i*2+1].(int64)
+		counts[i].Used = uint(used)
+		counts[i].Allowed = allowed == 1
+	}
+
+	return nil
+}
+
+// 카운터 키를 hash tag 형식으로 변경하기 전의 키, Tf:{TokenId}:{AppId}:{unit}, TfD:{TokenId}:{AppId}:{OperationId}:{unit}
+// 새 키, 소속된 집계 set을 반환하며 이미 새 형식이거나 형식이 맞지 않으면 ok가 false
+func legacyTrafficKey(key string) (newKey string, setKey string, ok bool) {
+	var tokenId, appId, operationId uint
+	var unit string
+
+	if rest := strings.TrimPrefix(key, constant.KeyTrafficDetailPrefix); rest != key {
+		if _, err := fmt.Sscanf(strings.ReplaceAll(rest, ":", " "), "%d %d %d %s", &tokenId, &appId, &operationId, &unit); err != nil || !isTrafficUnit(unit) {
+			return "", "", false
+		}
+		return TokenTrafficDetailKey(tokenId, appId, operationId, unit), constant.KeyTrafficDetailSet + unit, true
+	}
+
+	if rest := strings.TrimPrefix(key, constant.KeyTrafficPrefix); rest != key {
+		if _, err := fmt.Sscanf(strings.ReplaceAll(rest, ":", " "), "%d %d %s", &tokenId, &appId, &unit); err != nil || !isTrafficUnit(unit) {
+			return "", "", false
+		}
+		return TokenTrafficKey(tokenId, appId, unit), constant.KEY_TRAFFIC_SET + unit, true
+	}
+
+	return "", "", false
+}
+
+func isTrafficUnit(unit string) bool {
+	for _, u := range constant.GetTrafficUnits() {
+		if u == unit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KEYS: 이전 키, 새 키, 집계 set / 이전 키의 값을 새 키에 더하고 삭제, set의 멤버도 새 키로 교체
+// 여러 인스턴스가 동시에 실행해도 한 번만 더해지도록 스크립트로 실행
+var migrateTrafficKeyScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+if not value then
+	return 0
+end
+redis.call('INCRBY', KEYS[2], value)
+redis.call('DEL', KEYS[1])
+if redis.call('SREM', KEYS[3], KEYS[1]) == 1 then
+	redis.call('SADD', KEYS[3], KEYS[2])
+end
+return 1
+`)
+
+// MigrateTokenTrafficKeys 이전 형식의 카운터를 hash tag 형식의 키로 옮기고 옮긴 키 수를 반환
+// 이전 형식은 standalone 모드에서만 사용했으므로 standalone, sentinel 모드에서 기동시 실행
+func MigrateTokenTrafficKeys(ctx context.Context, rdb *database.RedisDB) (int, error) {
+	migrated := 0
+	for _, match := range []string{constant.KeyTrafficPrefix + "*", constant.KeyTrafficDetailPrefix + "*"} {
+		keys, err := rdb.ScanKeys(ctx, match)
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, key := range keys {
+			newKey, setKey, ok := legacyTrafficKey(key)
+			if !ok {
+				continue
+			}
+
+			result, err := rdb.RunScript(ctx, migrateTrafficKeyScript, []string{key, newKey, setKey})
+			if err != nil {
+				return migrated, err
+			}
+			if n, _ := result.(int64); n == 1 {
+				migrated++
+			}
+		}
+	}
+
+	return migrated, nil
+}
+
+func FindTrafficsByApp(ctx context.Context, orm xorm.Interface, appId uint) ([]Traffic, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
 	traffics := []Traffic{}
 
-	err := orm.Where("app_id = ?", appId).Find(&traffics)
+	err := session.Where("app_id = ?", appId).Find(&traffics)
 
 	if err != nil {
 		return nil, errors.New("database error; " + err.Error())
@@ -39,14 +204,17 @@ func FindTrafficsByApp(orm *xorm.Engine, appId uint) ([]Traffic, error) {
 	return traffics, nil
 }
 
-func (t *Traffic) Delete(orm *xorm.Engine) error {
-	if _, err := orm.ID(t.Id).Delete(t); err != nil {
+func (t *Traffic) Delete(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.ID(t.Id).Delete(t); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (t *Traffic) DelRedis(rdb *database.RedisDB) {
-	rdb.Delete(t.KeyName())
+func (t *Traffic) DelRedis(ctx context.Context, rdb *database.RedisDB) {
+	rdb.Delete(ctx, t.KeyName())
 }
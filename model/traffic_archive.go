@@ -0,0 +1,54 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// TrafficArchive App 삭제시 보관하는 Traffic, App 복구시 다시 등록하고 영구 삭제시 함께 삭제
+type TrafficArchive struct {
+	Id        uint `xorm:"pk autoincr"`
+	AppId     uint `xorm:"index"`
+	Unit      string
+	Val       uint
+	Seq       uint
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (TrafficArchive) TableName() string {
+	return "traffic_archive"
+}
+
+func NewTrafficArchive(traffic Traffic) TrafficArchive {
+	return TrafficArchive{AppId: traffic.AppId, Unit: traffic.Unit, Val: traffic.Val, Seq: traffic.Seq}
+}
+
+func (a *TrafficArchive) Traffic() Traffic {
+	return Traffic{AppId: a.AppId, Unit: a.Unit, Val: a.Val, Seq: a.Seq}
+}
+
+func FindTrafficArchivesByApp(ctx context.Context, orm xorm.Interface, appId uint) ([]TrafficArchive, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	archives := []TrafficArchive{}
+	if err := session.Where("app_id = ?", appId).Asc("seq").Find(&archives); err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	return archives, nil
+}
+
+func DeleteTrafficArchivesByApps(ctx context.Context, orm xorm.Interface, appIds ...uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.In("app_id", appIds).Delete(&TrafficArchive{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
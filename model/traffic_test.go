@@ -0,0 +1,176 @@
+package model
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+)
+
+func TestTokenTrafficKey(t *testing.T) {
+	key := TokenTrafficKey(12, 34, "hour")
+	if key != "Tf:{12:34}:hour" {
+		t.Errorf("TokenTrafficKey = %s", key)
+	}
+	if detail := TokenTrafficDetailKey(12, 34, 56, "hour"); detail != "TfD:{12:34}:56:hour" {
+		t.Errorf("TokenTrafficDetailKey = %s", detail)
+	}
+
+	tokenId, appId, unit, err := ParseTokenTrafficKey(key)
+	if err != nil || tokenId != 12 || appId != 34 || unit != "hour" {
+		t.Errorf("ParseTokenTrafficKey = %d %d %s %v", tokenId, appId, unit, err)
+	}
+	if _, _, _, err := ParseTokenTrafficKey("Tf:12:34:hour"); err == nil {
+		t.Error("legacy key must not be parsed as current format")
+	}
+}
+
+func TestLegacyTrafficKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		newKey string
+		setKey string
+		ok     bool
+	}{
+		{"Tf:12:34:hour", "Tf:{12:34}:hour", constant.KEY_TRAFFIC_SET + "hour", true},
+		{"TfD:12:34:56:month", "TfD:{12:34}:56:month", constant.KeyTrafficDetailSet + "month", true},
+		{"Tf:{12:34}:hour", "", "", false},
+		{"TfD:{12:34}:56:day", "", "", false},
+		{"Tf:12:34:week", "", "", false},
+		{"Tf:12:hour", "", "", false},
+		{"Token:abc", "", "", false},
+	}
+	for _, tt := range tests {
+		newKey, setKey, ok := legacyTrafficKey(tt.key)
+		if newKey != tt.newKey || setKey != tt.setKey || ok != tt.ok {
+			t.Errorf("legacyTrafficKey(%s) = %s, %s, %v", tt.key, newKey, setKey, ok)
+		}
+	}
+}
+
+// newTestRedisDB AUTHOR_TEST_REDIS_ADDR(예: localhost:6379)의 Redis를 사용, 설정하지 않으면 건너뜀
+// 테스트 전후로 선택한 DB(15)를 비움
+func newTestRedisDB(t *testing.T) *database.RedisDB {
+	t.Helper()
+
+	addr := os.Getenv("AUTHOR_TEST_REDIS_ADDR")
+	if len(addr) == 0 {
+		t.Skip("AUTHOR_TEST_REDIS_ADDR is not set")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr, DB: 15})
+	if err := client.FlushDB(context.Background()).Err(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		client.FlushDB(context.Background())
+		client.Close()
+	})
+
+	return database.NewRedisDB(client, time.Second, nil)
+}
+
+func TestIncrTokenTraffic(t *testing.T) {
+	rdb := newTestRedisDB(t)
+	ctx := context.Background()
+
+	for i := uint(1); i <= 3; i++ {
+		counts := []TrafficCount{{Unit: "hour", Limit: 2}, {Unit: "day", Limit: 5}}
+		if err := IncrTokenTraffic(ctx, rdb, 1, 2, 3, counts); err != nil {
+			t.Fatal(err)
+		}
+
+		// 허용치를 초과한 단위는 증가하지 않고, 나머지 단위는 증가
+		wantHour := i
+		if wantHour > 2 {
+			wantHour = 2
+		}
+		if counts[0].Used != wantHour || counts[0].Allowed != (i <= 2) {
+			t.Errorf("call %d hour = %+v", i, counts[0])
+		}
+		if counts[1].Used != i || !counts[1].Allowed {
+			t.Errorf("call %d day = %+v", i, counts[1])
+		}
+	}
+
+	if used, _ := rdb.GetUint(ctx, TokenTrafficDetailKey(1, 2, 3, "hour")); used != 2 {
+		t.Errorf("hour detail = %d, want 2", used)
+	}
+	if used, _ := rdb.GetUint(ctx, TokenTrafficDetailKey(1, 2, 3, "day")); used != 3 {
+		t.Errorf("day detail = %d, want 3", used)
+	}
+}
+
+// 동시 요청이 허용치를 넘지 않아야 함
+func TestIncrTokenTrafficConcurrent(t *testing.T) {
+	rdb := newTestRedisDB(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts := []TrafficCount{{Unit: "hour", Limit: 10}}
+			if err := IncrTokenTraffic(ctx, rdb, 1, 2, 3, counts); err != nil {
+				t.Error(err)
+				return
+			}
+			if counts[0].Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 10 {
+		t.Errorf("allowed = %d, want 10", allowed)
+	}
+	if used, _ := rdb.GetUint(ctx, TokenTrafficKey(1, 2, "hour")); used != 10 {
+		t.Errorf("counter = %d, want 10", used)
+	}
+}
+
+func TestMigrateTokenTrafficKeys(t *testing.T) {
+	rdb := newTestRedisDB(t)
+	ctx := context.Background()
+
+	rdb.Set(ctx, "Tf:1:2:hour", 4)
+	rdb.SAdd(ctx, constant.KEY_TRAFFIC_SET+"hour", "Tf:1:2:hour")
+	rdb.Set(ctx, "TfD:1:2:3:hour", 4)
+	// 새 형식으로 이미 증가된 카운터에 더해져야 함
+	rdb.Set(ctx, TokenTrafficKey(1, 2, "hour"), 1)
+
+	migrated, err := MigrateTokenTrafficKeys(ctx, rdb)
+	if err != nil || migrated != 2 {
+		t.Fatalf("MigrateTokenTrafficKeys = %d, %v", migrated, err)
+	}
+
+	if used, _ := rdb.GetUint(ctx, TokenTrafficKey(1, 2, "hour")); used != 5 {
+		t.Errorf("counter = %d, want 5", used)
+	}
+	if used, _ := rdb.GetUint(ctx, TokenTrafficDetailKey(1, 2, 3, "hour")); used != 4 {
+		t.Errorf("detail counter = %d, want 4", used)
+	}
+	if _, err := rdb.GetString(ctx, "Tf:1:2:hour"); err != redis.Nil {
+		t.Errorf("legacy key must be deleted, err = %v", err)
+	}
+
+	members, _ := rdb.SMembers(ctx, constant.KEY_TRAFFIC_SET+"hour")
+	if len(members) != 1 || members[0] != TokenTrafficKey(1, 2, "hour") {
+		t.Errorf("traffic set = %v", members)
+	}
+
+	if migrated, err := MigrateTokenTrafficKeys(ctx, rdb); err != nil || migrated != 0 {
+		t.Errorf("second run = %d, %v", migrated, err)
+	}
+}
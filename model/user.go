@@ -1,14 +1,27 @@
 package model
 
 import (
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/kekim-go/Author/constant"
 	errors "github.com/kekim-go/Author/error"
-	"golang.org/x/crypto/bcrypt"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"xorm.io/xorm"
 )
 
+// UserFilter : 회원 목록 조회 조건, 문자열 조건은 앞부분 일치 검색
+type UserFilter struct {
+	LoginId        string
+	Email          string
+	Name           string
+	IncludeDeleted bool
+	Page           int
+	PerPage        int
+}
+
 type User struct {
 	Id                  uint   `xorm:"pk autoincr"`
 	GroupId             uint   `xorm:"index"`
@@ -20,6 +33,9 @@ type User struct {
 	LastLoginAt         time.Time
 	ResetPasswordToken  string
 	ResetPasswordSentAt time.Time
+	PendingEmail        string
+	EmailVerifyToken    string `xorm:"index"`
+	EmailVerifySentAt   *time.Time
 	CreatedAt           time.Time  `xorm:"created"`
 	UpdatedAt           time.Time  `xorm:"updated"`
 	DeletedAt           *time.Time `xorm:"deleted index"`
@@ -29,8 +45,11 @@ func (User) TableName() string {
 	return "user"
 }
 
-func (u *User) Find(orm *xorm.Engine) error {
-	found, err := orm.Get(u)
+func (u *User) Find(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Get(u)
 
 	if err != nil {
 		return errors.NewWithPrefix(err, "database error")
@@ -43,31 +62,143 @@ func (u *User) Find(orm *xorm.Engine) error {
 	return nil
 }
 
-func EncryptPassword(password string) (string, error) {
-	enc, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
+func (u *User) GetGrpcRes() (*grpc_author.UserRes, error) {
+	res := &grpc_author.UserRes{
+		Code:         grpc_author.UserRes_VALID,
+		Id:           uint32(u.Id),
+		LoginId:      u.LoginId,
+		Email:        u.Email,
+		Name:         u.Name,
+		PendingEmail: u.PendingEmail,
+		LoginCount:   uint32(u.LoginCount),
 	}
 
-	return string(enc), nil
+	var err error
+	if !u.LastLoginAt.IsZero() {
+		if res.LastLoginAt, err = ptypes.TimestampProto(u.LastLoginAt); err != nil {
+			return nil, err
+		}
+	}
+	if res.CreatedAt, err = ptypes.TimestampProto(u.CreatedAt); err != nil {
+		return nil, err
+	}
+	if u.DeletedAt != nil {
+		if res.DeletedAt, err = ptypes.TimestampProto(*u.DeletedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
 }
 
-func ComparePasswords(hashedPwd string, plainPwd string) (bool, error) {
-	// Since we'll be getting the hashed password from the DB it
-	// will be a string so we'll need to convert it to a byte slice
-	byteHash := []byte(hashedPwd)
-	err := bcrypt.CompareHashAndPassword(byteHash, []byte(plainPwd))
+// 로그인 성공시 로그인 횟수 및 최종 로그인 시간 갱신
+func (u *User) UpdateLoginStat(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	now := time.Now()
+	if _, err := session.ID(u.Id).Incr("login_count").Cols("last_login_at").Update(&User{LastLoginAt: now}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	u.LoginCount++
+	u.LastLoginAt = now
+
+	return nil
+}
+
+// CheckLoginId 사용 중인 로그인 ID인지 확인
+// 탈퇴 회원도 unique 인덱스에 남아 있으므로 함께 확인하며, 탈퇴 회원의 ID는 다시 사용할 수 없음
+func CheckLoginId(ctx context.Context, orm xorm.Interface, loginId string) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	return session.Unscoped().Where("login_id = ?", loginId).Exist(&User{})
+}
+
+// CheckEmail 사용 중인 이메일인지 확인, CheckLoginId와 같이 탈퇴 회원 포함
+func CheckEmail(ctx context.Context, orm xorm.Interface, email string) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	return session.Unscoped().Where("email = ?", email).Exist(&User{})
+}
+
+// FindUserByEmailVerifyToken token은 이메일 확인 토큰의 해시 값
+func FindUserByEmailVerifyToken(ctx context.Context, orm xorm.Interface, token string) (*User, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if len(token) == 0 {
+		return nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	user := &User{}
+	found, err := session.Where("email_verify_token = ?", token).Get(user)
 	if err != nil {
-		return false, err
+		return nil, errors.NewWithPrefix(err, "database error")
 	}
 
-	return true, nil
+	if !found {
+		return nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	return user, nil
 }
 
-func CheckLoginId(orm *xorm.Engine, loginId string) (bool, error) {
-	return orm.Get(&User{LoginId: loginId})
+// FindUserByResetPasswordToken token은 재설정 토큰의 해시 값
+func FindUserByResetPasswordToken(ctx context.Context, orm xorm.Interface, token string) (*User, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if len(token) == 0 {
+		return nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	user := &User{}
+	found, err := session.Where("reset_password_token = ?", token).Get(user)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return nil, errors.NewWithCode(http.StatusNotFound, "user not found")
+	}
+
+	return user, nil
 }
 
-func CheckEmail(orm *xorm.Engine, email string) (bool, error) {
-	return orm.Get(&User{Email: email})
+func FindUsers(ctx context.Context, orm xorm.Interface, filter UserFilter) ([]User, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = constant.DefaultPageSize
+	} else if filter.PerPage > constant.MaxPageSize {
+		filter.PerPage = constant.MaxPageSize
+	}
+
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if filter.IncludeDeleted {
+		session = session.Unscoped()
+	}
+	if len(filter.LoginId) > 0 {
+		session = session.And("login_id LIKE ? ESCAPE '!'", likePrefix(filter.LoginId))
+	}
+	if len(filter.Email) > 0 {
+		session = session.And("email LIKE ? ESCAPE '!'", likePrefix(filter.Email))
+	}
+	if len(filter.Name) > 0 {
+		session = session.And("name LIKE ? ESCAPE '!'", likePrefix(filter.Name))
+	}
+
+	users := []User{}
+	total, err := session.Asc("id").Limit(filter.PerPage, (filter.Page-1)*filter.PerPage).FindAndCount(&users)
+	if err != nil {
+		return nil, 0, errors.NewWithPrefix(err, "database error")
+	}
+
+	return users, total, nil
 }
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// UserIdentity : 외부 IdP 계정과 회원 연결, (issuer, subject) 쌍은 하나의 회원에만 연결
+type UserIdentity struct {
+	Id          uint   `xorm:"pk autoincr"`
+	UserId      uint   `xorm:"index"`
+	Issuer      string `xorm:"unique(issuer_subject)"`
+	Subject     string `xorm:"unique(issuer_subject)"`
+	Email       string
+	LastLoginAt time.Time
+	CreatedAt   time.Time `xorm:"created"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identity"
+}
+
+func (i *UserIdentity) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	var err error
+	if i.Id == 0 {
+		_, err = session.Insert(i)
+	} else {
+		_, err = session.ID(i.Id).AllCols().Update(i)
+	}
+
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+func FindUserIdentity(ctx context.Context, orm xorm.Interface, issuer string, subject string) (*UserIdentity, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	identity := &UserIdentity{}
+	found, err := session.Where("issuer = ? AND subject = ?", issuer, subject).Get(identity)
+	if err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return nil, errors.NewWithCode(http.StatusNotFound, "identity not linked")
+	}
+
+	return identity, nil
+}
+
+func DeleteUserIdentitiesByUser(ctx context.Context, orm xorm.Interface, userId uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Where("user_id = ?", userId).Delete(&UserIdentity{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
+
+// UserMfa : 회원별 TOTP 2단계 인증 설정
+type UserMfa struct {
+	Id             uint `xorm:"pk autoincr"`
+	UserId         uint `xorm:"unique"`
+	Secret         string
+	Enabled        bool `xorm:"default false"`
+	LastUsedStep   int64
+	FailedAttempts int        `xorm:"notnull default 0"` // 연속 실패 횟수, 검증 전에 미리 증가
+	LockedUntil    *time.Time // 연속 실패 횟수 초과로 잠긴 경우 잠금 종료 시각
+	ConfirmedAt    *time.Time
+	CreatedAt      time.Time `xorm:"created"`
+	UpdatedAt      time.Time `xorm:"updated"`
+}
+
+func (UserMfa) TableName() string {
+	return "user_mfa"
+}
+
+func (m *UserMfa) FindByUser(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	found, err := session.Where("user_id = ?", m.UserId).Get(m)
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	if !found {
+		return errors.NewWithCode(http.StatusNotFound, "mfa not enrolled")
+	}
+
+	return nil
+}
+
+func (m *UserMfa) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	var err error
+	if m.Id == 0 {
+		_, err = session.Insert(m)
+	} else {
+		_, err = session.ID(m.Id).AllCols().Update(m)
+	}
+
+	if err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// UseStep 마지막으로 사용된 주기 번호보다 큰 경우에만 반영하고 실패 횟수 초기화
+// 같은 코드로 동시에 요청해도 하나만 성공
+func (m *UserMfa) UseStep(ctx context.Context, orm xorm.Interface, step int64) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	affected, err := session.ID(m.Id).Where("last_used_step < ?", step).
+		Cols("last_used_step", "failed_attempts").Update(&UserMfa{LastUsedStep: step})
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	if affected > 0 {
+		m.LastUsedStep = step
+		m.FailedAttempts = 0
+	}
+
+	return affected > 0, nil
+}
+
+// ReserveAttempt 검증 전에 실패 횟수를 먼저 증가시켜 동시 요청도 최대 횟수를 넘지 못하도록 함
+// 최대 횟수에 도달했으면 false, 검증에 성공하면 UseStep 또는 ResetAttempts로 초기화
+func (m *UserMfa) ReserveAttempt(ctx context.Context, orm xorm.Interface, max int) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	affected, err := session.ID(m.Id).Where("failed_attempts < ?", max).Incr("failed_attempts").Update(&UserMfa{})
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return affected > 0, nil
+}
+
+func (m *UserMfa) ResetAttempts(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.ID(m.Id).Cols("failed_attempts").Update(&UserMfa{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// Lock 잠금 종료 시각 기록, 이미 잠긴 경우 기존 시각 유지
+func (m *UserMfa) Lock(ctx context.Context, orm xorm.Interface, until time.Time) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.ID(m.Id).Where("locked_until IS NULL").Cols("locked_until").Update(&UserMfa{LockedUntil: &until}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// Unlock 잠금을 해제하고 실패 횟수를 현재 시도 1회로 설정, 동시에 요청한 경우 하나만 성공
+func (m *UserMfa) Unlock(ctx context.Context, orm xorm.Interface) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	affected, err := session.ID(m.Id).Where("locked_until IS NOT NULL").
+		Cols("failed_attempts", "locked_until").Update(&UserMfa{FailedAttempts: 1})
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return affected > 0, nil
+}
+
+func (m *UserMfa) Delete(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.ID(m.Id).Delete(&UserMfa{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
+}
+
+// 2단계 인증이 활성화된 회원인지 확인
+func IsMfaEnabled(ctx context.Context, orm xorm.Interface, userId uint) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	has, err := session.Where("user_id = ? AND enabled = ?", userId, true).Exist(&UserMfa{})
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return has, nil
+}
@@ -1,6 +1,12 @@
 package model
 
-import "time"
+import (
+	"context"
+	"time"
+
+	errors "github.com/kekim-go/Author/error"
+	"xorm.io/xorm"
+)
 
 type UserRole struct {
 	Id        uint      `xorm:"pk autoincr"`
@@ -11,3 +17,20 @@ type UserRole struct {
 	User User `xorm:"- extends"`
 	Role Role `xorm:"- extends"`
 }
+
+// 회원에게 주어진 이름의 역할이 부여되어 있는지 확인
+func HasRole(ctx context.Context, orm xorm.Interface, userId uint, roleName string) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	has, err := session.Table("user_role").Join(
+		"INNER", "role",
+		"user_role.role_id = role.id",
+	).Where("user_role.user_id = ? AND role.name = ?", userId, roleName).Exist()
+
+	if err != nil {
+		return false, errors.NewWithPrefix(err, "database error")
+	}
+
+	return has, nil
+}
@@ -1,13 +1,17 @@
 package model
 
 import (
+	"context"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/kekim-go/Author/constant"
-	grpc_author "github.com/kekim-go/Protobuf/gen/proto/author"
+	errors "github.com/kekim-go/Author/error"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
 	"xorm.io/xorm"
 )
 
@@ -28,16 +32,81 @@ type TokenClaims struct {
 	LoginId  string `json:"loginId"`
 	Email    string `json:"email"`
 	Username string `json:"username"`
+	ClientId string `json:"clientId,omitempty"` // OAuth2로 발급한 토큰의 클라이언트
+	Scope    string `json:"scope,omitempty"`
 	jwt.StandardClaims
 }
 
-func (ut *UserToken) Save(orm *xorm.Engine) error {
+// IdTokenClaims : OpenID Connect ID 토큰 클레임
+type IdTokenClaims struct {
+	Nonce             string `json:"nonce,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	jwt.StandardClaims
+}
+
+// NewIdTokenClaims scope(profile, email)에 따라 회원 정보를 포함한 ID 토큰 클레임 생성
+func NewIdTokenClaims(user *User, issuer string, clientId string, nonce string, scope string) *IdTokenClaims {
+	now := time.Now()
+
+	claims := &IdTokenClaims{
+		Nonce: nonce,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    issuer,
+			Subject:   strconv.FormatUint(uint64(user.Id), 10),
+			Audience:  clientId,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(constant.OAuthAccessTokenExpInterval).Unix(),
+		},
+	}
+	if HasScope(scope, ScopeProfile) {
+		claims.Name = user.Name
+		claims.PreferredUsername = user.LoginId
+	}
+	if HasScope(scope, ScopeEmail) {
+		claims.Email = user.Email
+	}
+
+	return claims
+}
+
+// SignTokenClaims 회원 로그인 및 OAuth2 토큰 발급에 공통으로 사용하는 JWT 서명
+func SignTokenClaims(claims *TokenClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(constant.JwtSecret))
+}
+
+// ParseTokenClaims JWT 서명 및 만료 시간 검증
+func ParseTokenClaims(tokenString string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.NewWithCode(http.StatusUnauthorized, "unexpected signing method")
+		}
+		return []byte(constant.JwtSecret), nil
+	})
+	if err != nil {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, err.Error())
+	}
+
+	if !token.Valid {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid token")
+	}
+
+	return claims, nil
+}
+
+func (ut *UserToken) Save(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
 	if ut.Id == 0 {
-		if _, err := orm.Insert(ut); err != nil {
+		if _, err := session.Insert(ut); err != nil {
 			return err
 		}
 	} else {
-		if _, err := orm.ID(ut.Id).Update(ut); err != nil {
+		if _, err := session.ID(ut.Id).Update(ut); err != nil {
 			return err
 		}
 	}
@@ -78,15 +147,56 @@ func (ut *UserToken) GetValidGrpcRes() (*grpc_author.AuthRes, error) {
 	}, nil
 }
 
-func (ut *UserToken) FindUserToken(orm *xorm.Engine) error {
-	if _, err := orm.Get(ut); err != nil {
+func (ut *UserToken) FindUserToken(ctx context.Context, orm xorm.Interface) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Get(ut); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func CheckRefreshToken(orm *xorm.Engine, refreshToken string) (bool, error) {
+func CheckRefreshToken(ctx context.Context, orm xorm.Interface, refreshToken string) (bool, error) {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
 	ut := &UserToken{RefreshToken: refreshToken}
-	return orm.Get(ut)
+	return session.Get(ut)
+}
+
+// 만료되지 않은 JWT에 해당하는 회원 조회
+func FindUserByJwt(ctx context.Context, orm xorm.Interface, jwt string) (*User, error) {
+	if len(jwt) == 0 {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid token")
+	}
+
+	ut := UserToken{Jwt: jwt}
+	if err := ut.FindUserToken(ctx, orm); err != nil {
+		return nil, errors.NewWithPrefix(err, "database error")
+	}
+
+	if ut.Id == 0 || ut.JwtExpiredAt == nil || time.Now().After(*ut.JwtExpiredAt) {
+		return nil, errors.NewWithCode(http.StatusUnauthorized, "invalid token")
+	}
+
+	user := &User{Id: ut.UserId}
+	if err := user.Find(ctx, orm); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// 회원의 로그인 토큰(JWT/Refresh Token) 전체 폐기
+func DeleteUserTokensByUser(ctx context.Context, orm xorm.Interface, userId uint) error {
+	session, cancel := Session(ctx, orm)
+	defer cancel()
+
+	if _, err := session.Where("user_id = ?", userId).Delete(&UserToken{}); err != nil {
+		return errors.NewWithPrefix(err, "database error")
+	}
+
+	return nil
 }
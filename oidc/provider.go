@@ -0,0 +1,144 @@
+// Package oidc OpenID Connect ID 토큰 서명 및 공개키(JWKS) 관리
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+
+	"github.com/dgrijalva/jwt-go"
+	errors "github.com/kekim-go/Author/error"
+)
+
+const SigningAlg = "RS256"
+
+// Config : OIDC 설정 (config.yaml의 oidc 항목)
+type Config struct {
+	Issuer            string `yaml:"issuer"`            // 필수, 외부에서 접근 가능한 HTTP 서버 주소 (예: https://author.example.com)
+	PrivateKeyFile    string `yaml:"privateKeyFile"`    // 필수, PEM 형식 RSA 개인키
+	AllowEphemeralKey bool   `yaml:"allowEphemeralKey"` // privateKeyFile 없이 실행시마다 임시 키 생성 (dev 모드 전용)
+}
+
+// JSONWebKey RFC 7517 공개키
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type Provider struct {
+	issuer    string
+	key       *rsa.PrivateKey
+	kid       string
+	Ephemeral bool // 임시 생성된 키 사용 여부, 재시작시 기존 ID 토큰 검증 불가
+}
+
+// NewProvider issuer와 개인키가 없으면 실패, 임시 키는 AllowEphemeralKey 설정시에만 사용
+func NewProvider(config Config) (*Provider, error) {
+	if err := validateIssuer(config.Issuer); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{issuer: config.Issuer}
+
+	var err error
+	if len(config.PrivateKeyFile) > 0 {
+		if p.key, err = loadPrivateKey(config.PrivateKeyFile); err != nil {
+			return nil, err
+		}
+	} else {
+		if !config.AllowEphemeralKey {
+			return nil, errors.New("oidc.privateKeyFile is required")
+		}
+		if p.key, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			return nil, err
+		}
+		p.Ephemeral = true
+	}
+
+	sum := sha256.Sum256(p.key.PublicKey.N.Bytes())
+	p.kid = base64.RawURLEncoding.EncodeToString(sum[:8])
+
+	return p, nil
+}
+
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+// Sign kid 헤더를 포함하여 RS256으로 서명
+func (p *Provider) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.kid
+
+	return token.SignedString(p.key)
+}
+
+func (p *Provider) JWKS() JSONWebKeySet {
+	pub := p.key.PublicKey
+
+	return JSONWebKeySet{Keys: []JSONWebKey{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: SigningAlg,
+		Kid: p.kid,
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}}
+}
+
+// issuer는 ID 토큰의 iss와 discovery 문서의 기준이 되므로 query, fragment 없는 http(s) URL만 허용
+func validateIssuer(issuer string) error {
+	if len(issuer) == 0 {
+		return errors.New("oidc.issuer is required")
+	}
+
+	u, err := url.Parse(issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || len(u.Host) == 0 ||
+		len(u.RawQuery) > 0 || len(u.Fragment) > 0 {
+		return errors.New("oidc.issuer must be an http(s) URL without query or fragment: " + issuer)
+	}
+
+	return nil
+}
+
+func loadPrivateKey(fileName string) (*rsa.PrivateKey, error) {
+	file, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(file)
+	if block == nil {
+		return nil, errors.New("invalid PEM file: " + fileName)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA: " + fileName)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const testIssuer = "https://author.example.com"
+
+func writeKey(t *testing.T, key *rsa.PrivateKey, pkcs8 bool) string {
+	t.Helper()
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if pkcs8 {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	fileName := filepath.Join(t.TempDir(), "oidc.pem")
+	if err := ioutil.WriteFile(fileName, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestNewProviderRequiresConfig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writeKey(t, key, false)
+
+	invalid := map[string]Config{
+		"no issuer":         {PrivateKeyFile: keyFile},
+		"relative issuer":   {Issuer: "author.example.com", PrivateKeyFile: keyFile},
+		"issuer with query": {Issuer: testIssuer + "?a=b", PrivateKeyFile: keyFile},
+		"no key":            {Issuer: testIssuer},
+		"missing key file":  {Issuer: testIssuer, PrivateKeyFile: filepath.Join(t.TempDir(), "none.pem")},
+	}
+	for name, config := range invalid {
+		if _, err := NewProvider(config); err == nil {
+			t.Errorf("%s: NewProvider must fail", name)
+		}
+	}
+
+	p, err := NewProvider(Config{Issuer: testIssuer, AllowEphemeralKey: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Ephemeral {
+		t.Error("provider without key file must use an ephemeral key")
+	}
+}
+
+func TestProviderKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pkcs8 := range []bool{false, true} {
+		p, err := NewProvider(Config{Issuer: testIssuer, PrivateKeyFile: writeKey(t, key, pkcs8)})
+		if err != nil {
+			t.Fatalf("pkcs8=%v: %v", pkcs8, err)
+		}
+		if p.Ephemeral || p.key.N.Cmp(key.N) != 0 {
+			t.Errorf("pkcs8=%v: configured key not loaded", pkcs8)
+		}
+	}
+}
+
+// JWKS 공개키로 서명한 토큰을 검증할 수 있어야 함
+func TestProviderSignVerifiesWithJWKS(t *testing.T) {
+	p, err := NewProvider(Config{Issuer: testIssuer, AllowEphemeralKey: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := p.Sign(jwt.StandardClaims{Issuer: p.Issuer(), Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := p.JWKS()
+	if len(jwks.Keys) != 1 {
+		t.Fatalf("JWKS keys = %d", len(jwks.Keys))
+	}
+	jwk := jwks.Keys[0]
+	n, _ := base64.RawURLEncoding.DecodeString(jwk.N)
+	e, _ := base64.RawURLEncoding.DecodeString(jwk.E)
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	claims := &jwt.StandardClaims{}
+	token, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Header["kid"] != jwk.Kid {
+			t.Errorf("kid = %v, want %s", token.Header["kid"], jwk.Kid)
+		}
+		return pub, nil
+	})
+	if err != nil || !token.Valid || claims.Issuer != testIssuer {
+		t.Errorf("verify = %v, %v", err, claims)
+	}
+}
@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	errors "github.com/kekim-go/Author/error"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const HashBcrypt = "bcrypt"
+const HashArgon2id = "argon2id"
+
+const argon2SaltLen = 16
+
+// PasswordHashConfig : 비밀번호 해시 설정 (config.yaml의 passwordHash 항목)
+type PasswordHashConfig struct {
+	Algorithm     string `yaml:"algorithm"` // bcrypt 또는 argon2id
+	BcryptCost    int    `yaml:"bcryptCost"`
+	Argon2Time    uint32 `yaml:"argon2Time"`
+	Argon2Memory  uint32 `yaml:"argon2Memory"` // KiB 단위
+	Argon2Threads uint8  `yaml:"argon2Threads"`
+	Argon2KeyLen  uint32 `yaml:"argon2KeyLen"`
+}
+
+// PasswordHasher 설정된 알고리즘으로 비밀번호를 해시하고, 저장된 해시의 형식을 보고 검증
+// bcrypt는 "$2a$"로 시작하는 기본 형식, argon2id는 "$argon2id$v=19$m=..,t=..,p=..$salt$hash" 형식을 사용
+type PasswordHasher struct {
+	config PasswordHashConfig
+}
+
+func NewPasswordHasher(config PasswordHashConfig) (*PasswordHasher, error) {
+	if len(config.Algorithm) == 0 {
+		config.Algorithm = HashBcrypt
+	}
+	if config.BcryptCost == 0 {
+		config.BcryptCost = bcrypt.DefaultCost
+	}
+	if config.Argon2Time == 0 {
+		config.Argon2Time = 1
+	}
+	if config.Argon2Memory == 0 {
+		config.Argon2Memory = 64 * 1024
+	}
+	if config.Argon2Threads == 0 {
+		config.Argon2Threads = 4
+	}
+	if config.Argon2KeyLen == 0 {
+		config.Argon2KeyLen = 32
+	}
+
+	if config.Algorithm != HashBcrypt && config.Algorithm != HashArgon2id {
+		return nil, errors.New("unsupported password hash algorithm: " + config.Algorithm)
+	}
+	if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+		return nil, errors.New(fmt.Sprintf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+
+	return &PasswordHasher{config: config}, nil
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.config.Algorithm == HashArgon2id {
+		salt := make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+
+		params := argon2Params{
+			time: h.config.Argon2Time, memory: h.config.Argon2Memory,
+			threads: h.config.Argon2Threads, keyLen: h.config.Argon2KeyLen,
+		}
+		key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+
+		return params.encode(salt, key), nil
+	}
+
+	enc, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(enc), nil
+}
+
+// Compare 저장된 해시 형식에 맞는 알고리즘으로 비밀번호 일치 여부 확인
+func (h *PasswordHasher) Compare(hashedPwd string, plainPwd string) (bool, error) {
+	if strings.HasPrefix(hashedPwd, "$"+HashArgon2id+"$") {
+		params, salt, key, err := decodeArgon2(hashedPwd)
+		if err != nil {
+			return false, err
+		}
+
+		other := argon2.IDKey([]byte(plainPwd), salt, params.time, params.memory, params.threads, params.keyLen)
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return false, errors.New("password mismatch")
+		}
+
+		return true, nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hashedPwd), []byte(plainPwd)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// NeedsRehash 저장된 해시가 현재 설정과 다른 알고리즘이거나 더 약한 파라미터를 사용하는지 확인
+func (h *PasswordHasher) NeedsRehash(hashedPwd string) bool {
+	if strings.HasPrefix(hashedPwd, "$"+HashArgon2id+"$") {
+		if h.config.Algorithm != HashArgon2id {
+			return true
+		}
+
+		params, _, _, err := decodeArgon2(hashedPwd)
+		if err != nil {
+			return true
+		}
+
+		return params.time < h.config.Argon2Time || params.memory < h.config.Argon2Memory ||
+			params.threads < h.config.Argon2Threads || params.keyLen < h.config.Argon2KeyLen
+	}
+
+	if h.config.Algorithm != HashBcrypt {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPwd))
+	if err != nil {
+		return true
+	}
+
+	return cost < h.config.BcryptCost
+}
+
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	keyLen  uint32
+}
+
+func (p argon2Params) encode(salt []byte, key []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", HashArgon2id, argon2.Version,
+		p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2(hashedPwd string) (params argon2Params, salt []byte, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(hashedPwd, "$")
+	if len(parts) != 6 {
+		err = errors.New("invalid argon2id hash format")
+		return
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return
+	}
+	if version != argon2.Version {
+		err = errors.New(fmt.Sprintf("unsupported argon2 version: %d", version))
+		return
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return
+	}
+	// argon2.IDKey는 t, p가 0이면 panic
+	if params.time < 1 || params.threads < 1 {
+		err = errors.New("invalid argon2id parameters")
+		return
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return
+	}
+	// 빈 해시는 어떤 비밀번호와도 일치하므로 거부
+	if len(salt) == 0 || len(key) == 0 {
+		err = errors.New("invalid argon2id hash format")
+		return
+	}
+	params.keyLen = uint32(len(key))
+
+	return
+}
@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestHasher(t *testing.T, config PasswordHashConfig) *PasswordHasher {
+	t.Helper()
+
+	h, err := NewPasswordHasher(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+func TestPasswordHasherRoundTrip(t *testing.T) {
+	configs := map[string]PasswordHashConfig{
+		"bcrypt":   {Algorithm: HashBcrypt, BcryptCost: bcrypt.MinCost},
+		"argon2id": {Algorithm: HashArgon2id, Argon2Memory: 1024, Argon2Threads: 1},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			h := newTestHasher(t, config)
+
+			hashed, err := h.Hash("correct horse")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name == HashArgon2id && !strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=1,p=1$") {
+				t.Errorf("unexpected argon2id hash format: %s", hashed)
+			}
+
+			if matched, err := h.Compare(hashed, "correct horse"); !matched || err != nil {
+				t.Errorf("Compare(correct) = %v, %v", matched, err)
+			}
+			if matched, err := h.Compare(hashed, "wrong horse"); matched || err == nil {
+				t.Errorf("Compare(wrong) = %v, %v", matched, err)
+			}
+
+			// 같은 비밀번호라도 salt가 달라 해시가 달라야 함
+			if other, _ := h.Hash("correct horse"); other == hashed {
+				t.Error("hash must be salted")
+			}
+
+			if h.NeedsRehash(hashed) {
+				t.Error("hash with current settings should not need rehash")
+			}
+		})
+	}
+}
+
+// 설정된 알고리즘과 달라도 저장된 해시 형식으로 검증
+func TestPasswordHasherCompareOtherAlgorithm(t *testing.T) {
+	bcryptHasher := newTestHasher(t, PasswordHashConfig{BcryptCost: bcrypt.MinCost})
+	argon2Hasher := newTestHasher(t, PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 1024, Argon2Threads: 1})
+
+	bcryptHash, _ := bcryptHasher.Hash("secret")
+	argon2Hash, _ := argon2Hasher.Hash("secret")
+
+	if matched, _ := argon2Hasher.Compare(bcryptHash, "secret"); !matched {
+		t.Error("argon2id hasher should verify bcrypt hashes")
+	}
+	if matched, _ := bcryptHasher.Compare(argon2Hash, "secret"); !matched {
+		t.Error("bcrypt hasher should verify argon2id hashes")
+	}
+}
+
+func TestPasswordHasherNeedsRehash(t *testing.T) {
+	weakBcrypt, _ := newTestHasher(t, PasswordHashConfig{BcryptCost: bcrypt.MinCost}).Hash("secret")
+	weakArgon2, _ := newTestHasher(t, PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 1024, Argon2Threads: 1}).Hash("secret")
+
+	bcryptHasher := newTestHasher(t, PasswordHashConfig{BcryptCost: bcrypt.MinCost + 1})
+	argon2Hasher := newTestHasher(t, PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 2048, Argon2Threads: 1})
+
+	tests := []struct {
+		name   string
+		hasher *PasswordHasher
+		hashed string
+		want   bool
+	}{
+		{"bcrypt lower cost", bcryptHasher, weakBcrypt, true},
+		{"bcrypt to argon2id", argon2Hasher, weakBcrypt, true},
+		{"argon2id to bcrypt", bcryptHasher, weakArgon2, true},
+		{"argon2id lower memory", argon2Hasher, weakArgon2, true},
+		{"argon2id higher memory", newTestHasher(t, PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 512, Argon2Threads: 1}), weakArgon2, false},
+		{"malformed", bcryptHasher, "not a hash", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.hasher.NeedsRehash(tt.hashed); got != tt.want {
+			t.Errorf("%s: NeedsRehash = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordHasherRejectsMalformedArgon2(t *testing.T) {
+	h := newTestHasher(t, PasswordHashConfig{Algorithm: HashArgon2id})
+
+	// salt: "saltsaltsaltsalt", key: "keykeykeykeykeykey"
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5"
+	hashes := []string{
+		"$argon2id$v=19$m=1024,t=0,p=1$" + salt + "$" + key,
+		"$argon2id$v=19$m=1024,t=1,p=0$" + salt + "$" + key,
+		"$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$",
+		"$argon2id$v=19$m=1024,t=1,p=1$$" + key,
+		"$argon2id$v=18$m=1024,t=1,p=1$" + salt + "$" + key,
+		"$argon2id$v=19$m=1024,t=1$" + salt + "$" + key,
+		"$argon2id$v=19$m=1024,t=1,p=1$" + salt,
+	}
+
+	for _, hashed := range hashes {
+		if matched, err := h.Compare(hashed, ""); matched || err == nil {
+			t.Errorf("Compare(%q) = %v, %v, want error", hashed, matched, err)
+		}
+	}
+}
+
+func TestNewPasswordHasherInvalidConfig(t *testing.T) {
+	for _, config := range []PasswordHashConfig{
+		{Algorithm: "md5"},
+		{BcryptCost: bcrypt.MaxCost + 1},
+	} {
+		if _, err := NewPasswordHasher(config); err == nil {
+			t.Errorf("NewPasswordHasher(%+v) should fail", config)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+)
+
+// PasswordPolicyConfig : 비밀번호 정책 설정 (config.yaml의 passwordPolicy 항목)
+type PasswordPolicyConfig struct {
+	MinLength     int    `yaml:"minLength"`
+	MaxLength     int    `yaml:"maxLength"`
+	RequireUpper  bool   `yaml:"requireUpper"`
+	RequireLower  bool   `yaml:"requireLower"`
+	RequireDigit  bool   `yaml:"requireDigit"`
+	RequireSymbol bool   `yaml:"requireSymbol"`
+	BannedFile    string `yaml:"bannedFile"`  // 한 줄에 하나씩 금지 비밀번호를 기록한 파일, '#'으로 시작하는 줄은 무시
+	HistorySize   int    `yaml:"historySize"` // 재사용을 금지할 최근 비밀번호 개수, 0이면 검사하지 않음
+}
+
+// Violation 비밀번호 정책 위반 내역
+type Violation struct {
+	Rule grpc_author.PasswordViolation_Rule
+	Msg  string
+}
+
+func (v Violation) GetGrpcViolation() *grpc_author.PasswordViolation {
+	return &grpc_author.PasswordViolation{Rule: v.Rule, Msg: v.Msg}
+}
+
+type PasswordPolicy struct {
+	config PasswordPolicyConfig
+	banned map[string]struct{}
+}
+
+func NewPasswordPolicy(config PasswordPolicyConfig) (*PasswordPolicy, error) {
+	p := &PasswordPolicy{config: config, banned: map[string]struct{}{}}
+
+	if len(config.BannedFile) > 0 {
+		if err := p.loadBannedFile(config.BannedFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *PasswordPolicy) HistorySize() int {
+	return p.config.HistorySize
+}
+
+// Validate 저장 이력과 무관한 규칙(길이, 문자 종류, 금지 목록)을 검사
+func (p *PasswordPolicy) Validate(password string) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+	if p.config.MinLength > 0 && length < p.config.MinLength {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_TOO_SHORT,
+			Msg:  fmt.Sprintf("password must be at least %d characters", p.config.MinLength),
+		})
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_TOO_LONG,
+			Msg:  fmt.Sprintf("password must be at most %d characters", p.config.MaxLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.config.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_MISSING_UPPER,
+			Msg:  "password must contain an uppercase letter",
+		})
+	}
+	if p.config.RequireLower && !hasLower {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_MISSING_LOWER,
+			Msg:  "password must contain a lowercase letter",
+		})
+	}
+	if p.config.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_MISSING_DIGIT,
+			Msg:  "password must contain a digit",
+		})
+	}
+	if p.config.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_MISSING_SYMBOL,
+			Msg:  "password must contain a symbol",
+		})
+	}
+
+	if _, ok := p.banned[strings.ToLower(password)]; ok {
+		violations = append(violations, Violation{
+			Rule: grpc_author.PasswordViolation_BANNED,
+			Msg:  "password is too common",
+		})
+	}
+
+	return violations
+}
+
+func (p *PasswordPolicy) loadBannedFile(fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.banned[strings.ToLower(line)] = struct{}{}
+	}
+
+	return scanner.Err()
+}
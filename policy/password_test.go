@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+)
+
+func rules(violations []Violation) map[grpc_author.PasswordViolation_Rule]bool {
+	m := map[grpc_author.PasswordViolation_Rule]bool{}
+	for _, v := range violations {
+		m[v.Rule] = true
+	}
+
+	return m
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	p, err := NewPasswordPolicy(PasswordPolicyConfig{
+		MinLength:     8,
+		MaxLength:     16,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		password string
+		want     []grpc_author.PasswordViolation_Rule
+	}{
+		{"Passw0rd!", nil},
+		{"Pa0!", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_TOO_SHORT}},
+		{"Passw0rd!Passw0rd!", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_TOO_LONG}},
+		{"passw0rd!", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_MISSING_UPPER}},
+		{"PASSW0RD!", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_MISSING_LOWER}},
+		{"Password!", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_MISSING_DIGIT}},
+		{"Passw0rd1", []grpc_author.PasswordViolation_Rule{grpc_author.PasswordViolation_MISSING_SYMBOL}},
+		// 길이는 바이트가 아닌 문자 수로 계산
+		{"Pässwörd1!", nil},
+		{"", []grpc_author.PasswordViolation_Rule{
+			grpc_author.PasswordViolation_TOO_SHORT,
+			grpc_author.PasswordViolation_MISSING_UPPER,
+			grpc_author.PasswordViolation_MISSING_LOWER,
+			grpc_author.PasswordViolation_MISSING_DIGIT,
+			grpc_author.PasswordViolation_MISSING_SYMBOL,
+		}},
+	}
+
+	for _, tt := range tests {
+		got := rules(p.Validate(tt.password))
+		if len(got) != len(tt.want) {
+			t.Errorf("Validate(%q) = %v, want %v", tt.password, got, tt.want)
+			continue
+		}
+		for _, rule := range tt.want {
+			if !got[rule] {
+				t.Errorf("Validate(%q) missing %v", tt.password, rule)
+			}
+		}
+	}
+}
+
+func TestPasswordPolicyNoRules(t *testing.T) {
+	p, err := NewPasswordPolicy(PasswordPolicyConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if violations := p.Validate("a"); len(violations) != 0 {
+		t.Errorf("Validate = %v, want none", violations)
+	}
+}
+
+func TestPasswordPolicyBannedFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "banned.txt")
+	if err := ioutil.WriteFile(fileName, []byte("# common passwords\n\nPassword1\n  qwerty123  \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPasswordPolicy(PasswordPolicyConfig{BannedFile: fileName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, password := range []string{"password1", "PASSWORD1", "qwerty123"} {
+		if !rules(p.Validate(password))[grpc_author.PasswordViolation_BANNED] {
+			t.Errorf("Validate(%q) should be banned", password)
+		}
+	}
+	for _, password := range []string{"# common passwords", "", "qwerty1234"} {
+		if rules(p.Validate(password))[grpc_author.PasswordViolation_BANNED] {
+			t.Errorf("Validate(%q) should not be banned", password)
+		}
+	}
+
+	if _, err := NewPasswordPolicy(PasswordPolicyConfig{BannedFile: filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("missing banned file should fail")
+	}
+}
@@ -0,0 +1,93 @@
+// Package ratelimit Redis를 사용할 수 없을 때 인스턴스 메모리로 트래픽을 제한
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// LocalLimiter 단위(hour, day, month) 구간별 호출 횟수를 메모리에 기록
+// 인스턴스마다 따로 집계하므로 여러 인스턴스 운영 시 전체 허용치는 인스턴스 수만큼 늘어남
+type LocalLimiter struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep string
+}
+
+type counter struct {
+	window string
+	used   uint
+}
+
+func NewLocalLimiter() *LocalLimiter {
+	return &LocalLimiter{counters: map[string]*counter{}}
+}
+
+// Allow key의 현재 구간 사용량이 limit 미만이면 1 증가 후 true 반환
+// 이번 호출을 포함한 사용량(초과 시 현재 사용량)을 함께 반환
+func (l *LocalLimiter) Allow(key string, unit string, limit uint) (uint, bool) {
+	return l.allow(key, unit, limit, time.Now())
+}
+
+func (l *LocalLimiter) allow(key string, unit string, limit uint, now time.Time) (uint, bool) {
+	window := windowOf(unit, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	c, ok := l.counters[key]
+	if !ok || c.window != window {
+		c = &counter{window: window}
+		l.counters[key] = c
+	}
+
+	if c.used >= limit {
+		return c.used, false
+	}
+	c.used++
+
+	return c.used, true
+}
+
+// 한 시간에 한 번 지난 구간의 카운터 삭제
+func (l *LocalLimiter) sweep(now time.Time) {
+	hour := now.Format("2006010215")
+	if hour == l.lastSweep {
+		return
+	}
+	l.lastSweep = hour
+
+	for key, c := range l.counters {
+		if c.window != windowOf(unitOf(c.window), now) {
+			delete(l.counters, key)
+		}
+	}
+}
+
+func windowOf(unit string, t time.Time) string {
+	switch unit {
+	case "hour":
+		return t.Format("2006010215")
+	case "day":
+		return t.Format("20060102")
+	case "month":
+		return t.Format("200601")
+	default:
+		return ""
+	}
+}
+
+func unitOf(window string) string {
+	switch len(window) {
+	case len("2006010215"):
+		return "hour"
+	case len("20060102"):
+		return "day"
+	case len("200601"):
+		return "month"
+	default:
+		return ""
+	}
+}
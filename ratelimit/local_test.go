@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocalLimiterAllow(t *testing.T) {
+	l := NewLocalLimiter()
+	now := time.Date(2020, 8, 10, 13, 20, 0, 0, time.Local)
+
+	for i := uint(1); i <= 3; i++ {
+		if used, ok := l.allow("a", "hour", 3, now); !ok || used != i {
+			t.Errorf("call %d = %d, %v", i, used, ok)
+		}
+	}
+	if used, ok := l.allow("a", "hour", 3, now); ok || used != 3 {
+		t.Errorf("over limit = %d, %v, want 3, false", used, ok)
+	}
+
+	// 키별로 따로 집계
+	if used, ok := l.allow("b", "hour", 3, now); !ok || used != 1 {
+		t.Errorf("other key = %d, %v", used, ok)
+	}
+
+	if used, ok := l.allow("c", "hour", 0, now); ok || used != 0 {
+		t.Errorf("zero limit = %d, %v", used, ok)
+	}
+}
+
+// 단위 구간이 바뀌면 사용량 초기화
+func TestLocalLimiterWindow(t *testing.T) {
+	tests := []struct {
+		unit string
+		same time.Time
+		next time.Time
+	}{
+		{"hour", time.Date(2020, 8, 10, 13, 59, 59, 0, time.Local), time.Date(2020, 8, 10, 14, 0, 0, 0, time.Local)},
+		{"day", time.Date(2020, 8, 10, 23, 59, 0, 0, time.Local), time.Date(2020, 8, 11, 0, 0, 0, 0, time.Local)},
+		{"month", time.Date(2020, 8, 31, 23, 0, 0, 0, time.Local), time.Date(2020, 9, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		l := NewLocalLimiter()
+		start := time.Date(2020, 8, 10, 13, 0, 0, 0, time.Local)
+		if tt.unit == "month" {
+			start = time.Date(2020, 8, 1, 0, 0, 0, 0, time.Local)
+		}
+
+		l.allow("key", tt.unit, 1, start)
+		if _, ok := l.allow("key", tt.unit, 1, tt.same); ok {
+			t.Errorf("%s: same window must be limited", tt.unit)
+		}
+		if used, ok := l.allow("key", tt.unit, 1, tt.next); !ok || used != 1 {
+			t.Errorf("%s: next window = %d, %v", tt.unit, used, ok)
+		}
+	}
+}
+
+// 지난 구간의 카운터는 정리되고, 진행 중인 구간의 카운터는 유지
+func TestLocalLimiterSweep(t *testing.T) {
+	l := NewLocalLimiter()
+	now := time.Date(2020, 8, 10, 13, 0, 0, 0, time.Local)
+
+	l.allow("hourly", "hour", 10, now)
+	l.allow("daily", "day", 10, now)
+
+	l.allow("other", "hour", 10, now.Add(time.Hour))
+
+	if _, ok := l.counters["hourly"]; ok {
+		t.Error("expired hour counter must be swept")
+	}
+	if c, ok := l.counters["daily"]; !ok || c.used != 1 {
+		t.Error("current day counter must be kept")
+	}
+}
+
+func TestLocalLimiterConcurrent(t *testing.T) {
+	l := NewLocalLimiter()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := l.Allow("key", "day", 20); ok {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 20 {
+		t.Errorf("allowed = %d, want 20", allowed)
+	}
+}
@@ -0,0 +1,121 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 Appendix B의 SHA1 비밀키 "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// RFC 6238 Appendix B의 8자리 값에서 마지막 6자리
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		code, err := Code(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != v.code {
+			t.Errorf("Code(T=%d) = %s, want %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestValidateRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		now := time.Unix(v.unix, 0)
+		step, ok := Validate(rfcSecret, v.code, now)
+		if !ok || step != Step(now) {
+			t.Errorf("Validate(T=%d) = %d, %v, want %d, true", v.unix, step, ok, Step(now))
+		}
+
+		// 비밀키는 소문자로 입력해도 됨
+		if _, ok := Validate(strings.ToLower(rfcSecret), v.code, now); !ok {
+			t.Errorf("Validate(T=%d) with lower case secret failed", v.unix)
+		}
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	for offset := int64(-3); offset <= 3; offset++ {
+		code, err := Code(rfcSecret, current+offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		step, ok := Validate(rfcSecret, code, now)
+		want := offset >= -Skew && offset <= Skew
+		if ok != want {
+			t.Errorf("offset %d: ok = %v, want %v", offset, ok, want)
+		}
+		// 재사용 방지를 위해 호출측에서 비교할 수 있도록 코드가 생성된 주기 번호를 반환
+		if ok && step != current+offset {
+			t.Errorf("offset %d: step = %d, want %d", offset, step, current+offset)
+		}
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870822", "000000", "abcdef"} {
+		if _, ok := Validate(rfcSecret, code, now); ok {
+			t.Errorf("Validate(%q) should fail", code)
+		}
+	}
+
+	if _, ok := Validate("not base32!", "287082", now); ok {
+		t.Error("Validate with invalid secret should fail")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != secretSize {
+		t.Errorf("secret size = %d, want %d", len(key), secretSize)
+	}
+
+	if other, _ := GenerateSecret(); other == secret {
+		t.Error("secrets must be random")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Data Infuser", "alice", rfcSecret)
+
+	for _, want := range []string{
+		"otpauth://totp/Data%20Infuser:alice?",
+		"secret=" + rfcSecret,
+		"issuer=Data+Infuser",
+		"digits=6",
+		"period=30",
+	} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("URI %s does not contain %s", uri, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc/codes"
+	"xorm.io/xorm/contexts"
+)
+
+// RedisHook Redis 명령별 span 생성
+// 키와 값에 토큰이 포함되므로 명령 이름만 기록
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = tracer().Start(ctx, "redis "+cmd.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(kv.String("db.system", "redis"), kv.String("db.operation", cmd.Name())),
+	)
+
+	return ctx, nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endSpan(ctx, redisError(cmd.Err()))
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.Name())
+	}
+
+	ctx, _ = tracer().Start(ctx, "redis pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(kv.String("db.system", "redis"), kv.String("db.operation", strings.Join(names, " "))),
+	)
+
+	return ctx, nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if err = redisError(cmd.Err()); err != nil {
+			break
+		}
+	}
+	endSpan(ctx, err)
+
+	return nil
+}
+
+// 키가 없는 경우(redis.Nil)는 오류로 기록하지 않음
+func redisError(err error) error {
+	if err == redis.Nil {
+		return nil
+	}
+
+	return err
+}
+
+// XormHook SQL 실행별 span 생성, 인자 값은 기록하지 않음
+type XormHook struct {
+	system string
+}
+
+var _ contexts.Hook = (*XormHook)(nil)
+
+// NewXormHook dbType은 db.system 속성으로 기록 (mysql 등)
+func NewXormHook(dbType string) *XormHook {
+	return &XormHook{system: dbType}
+}
+
+func (h *XormHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	ctx := c.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, _ = tracer().Start(ctx, sqlOperation(c.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(kv.String("db.system", h.system), kv.String("db.statement", c.SQL)),
+	)
+
+	return ctx, nil
+}
+
+func (h *XormHook) AfterProcess(c *contexts.ContextHook) error {
+	endSpan(c.Ctx, c.Err)
+	return nil
+}
+
+// span 이름으로 사용할 SQL 첫 단어 (SELECT, UPDATE 등)
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "sql"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+func endSpan(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.SetStatus(codes.Unknown, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,113 @@
+// Package tracing OpenTelemetry 분산 추적 설정 및 gRPC, xorm, Redis 계측
+package tracing
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/trace/stdout"
+	"go.opentelemetry.io/otel/instrumentation/grpctrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	ExporterOtlp   = "otlp"
+	ExporterStdout = "stdout"
+
+	tracerName         = "github.com/kekim-go/Author"
+	defaultServiceName = "author"
+	defaultEndpoint    = "localhost:55680"
+)
+
+// Config : 분산 추적 설정, exporter가 비어 있으면 추적하지 않음
+type Config struct {
+	Exporter    string  `yaml:"exporter"`    // otlp 또는 stdout
+	Endpoint    string  `yaml:"endpoint"`    // OTLP collector gRPC 주소, 기본값 localhost:55680
+	Insecure    bool    `yaml:"insecure"`    // collector 연결에 TLS를 사용하지 않음
+	ServiceName string  `yaml:"serviceName"` // 기본값 author
+	SampleRatio float64 `yaml:"sampleRatio"` // 상위 span이 없는 요청의 수집 비율, 0 이하 또는 1 이상이면 모두 수집
+}
+
+// Init 설정된 exporter로 전역 TraceProvider를 등록하고, 남은 span을 내보내는 종료 함수 반환
+func Init(config Config) (func(), error) {
+	if len(config.Exporter) == 0 {
+		return func() {}, nil
+	}
+
+	serviceName := config.ServiceName
+	if len(serviceName) == 0 {
+		serviceName = defaultServiceName
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 && config.SampleRatio < 1 {
+		sampler = sdktrace.ProbabilitySampler(config.SampleRatio)
+	}
+
+	provider, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.ParentSample(sampler)}),
+		sdktrace.WithResource(resource.New(standard.ServiceNameKey.String(serviceName))),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var shutdown func()
+	switch config.Exporter {
+	case ExporterStdout:
+		exporter, err := stdout.NewExporter(stdout.Options{})
+		if err != nil {
+			return nil, err
+		}
+		processor := sdktrace.NewSimpleSpanProcessor(exporter)
+		provider.RegisterSpanProcessor(processor)
+		shutdown = func() { provider.UnregisterSpanProcessor(processor) }
+	case ExporterOtlp:
+		endpoint := config.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = defaultEndpoint
+		}
+
+		opts := []otlp.ExporterOption{otlp.WithAddress(endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlp.WithInsecure())
+		}
+		exporter, err := otlp.NewExporter(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		processor, err := sdktrace.NewBatchSpanProcessor(exporter)
+		if err != nil {
+			exporter.Stop()
+			return nil, err
+		}
+		provider.RegisterSpanProcessor(processor)
+
+		// processor 해제시 대기 중인 span을 내보낸 후 collector 연결 종료
+		shutdown = func() {
+			provider.UnregisterSpanProcessor(processor)
+			exporter.Stop()
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", config.Exporter)
+	}
+
+	global.SetTraceProvider(provider)
+
+	return shutdown, nil
+}
+
+// UnaryServerInterceptor 요청 메타데이터의 trace context(W3C traceparent)를 이어받아 RPC별 span 생성
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return grpctrace.UnaryServerInterceptor(tracer())
+}
+
+func tracer() trace.Tracer {
+	return global.Tracer(tracerName)
+}
@@ -0,0 +1,178 @@
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/api/global"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/codes"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// spanRecorder 종료된 span을 순서대로 보관
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*export.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(_ context.Context, span *export.SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) reset() []*export.SpanData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	spans := r.spans
+	r.spans = nil
+
+	return spans
+}
+
+var (
+	recorder     = new(spanRecorder)
+	recorderOnce sync.Once
+)
+
+// 전역 TraceProvider는 한 번만 등록할 수 있으므로 테스트 전체에서 공유
+func recordSpans(t *testing.T) *spanRecorder {
+	t.Helper()
+
+	var err error
+	recorderOnce.Do(func() {
+		var provider *sdktrace.Provider
+		provider, err = sdktrace.NewProvider(
+			sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+			sdktrace.WithSyncer(recorder),
+		)
+		if err == nil {
+			global.SetTraceProvider(provider)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.reset()
+
+	return recorder
+}
+
+func attribute(span *export.SpanData, key string) string {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value.AsString()
+		}
+	}
+
+	return ""
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init(Config{})
+	if err != nil || shutdown == nil {
+		t.Errorf("without exporter: %v", err)
+	}
+
+	if _, err := Init(Config{Exporter: "zipkin"}); err == nil {
+		t.Error("unknown exporter accepted")
+	}
+}
+
+func TestSqlOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM app":         "SELECT",
+		"  update app SET name = ?": "UPDATE",
+		"":                          "sql",
+	}
+	for sql, want := range tests {
+		if got := sqlOperation(sql); got != want {
+			t.Errorf("sqlOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
+
+// SQL 문장은 기록하되 인자 값은 기록하지 않고, 실행 오류는 span 상태로 기록
+func TestXormHook(t *testing.T) {
+	recorder := recordSpans(t)
+
+	orm, err := xorm.NewEngine("sqlite3", filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer orm.Close()
+	orm.AddHook(NewXormHook("sqlite3"))
+
+	if _, err := orm.Context(context.Background()).Exec("CREATE TABLE app (name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orm.Context(context.Background()).Exec("INSERT INTO app (name) VALUES (?)", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orm.Context(context.Background()).Exec("SELECT * FROM missing"); err == nil {
+		t.Fatal("query on missing table succeeded")
+	}
+
+	spans := recorder.reset()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want 3", len(spans))
+	}
+	for i, want := range []string{"CREATE", "INSERT", "SELECT"} {
+		if spans[i].Name != want || attribute(spans[i], "db.system") != "sqlite3" {
+			t.Errorf("span %d = %q (%s), want %q", i, spans[i].Name, attribute(spans[i], "db.system"), want)
+		}
+	}
+	if statement := attribute(spans[1], "db.statement"); statement != "INSERT INTO app (name) VALUES (?)" {
+		t.Errorf("db.statement = %q", statement)
+	}
+	if spans[1].StatusCode != codes.OK || spans[2].StatusCode != codes.Unknown {
+		t.Errorf("status = %v, %v, want OK, Unknown", spans[1].StatusCode, spans[2].StatusCode)
+	}
+}
+
+// 키가 없는 경우(redis.Nil)는 오류로 기록하지 않고, 키는 기록하지 않음
+func TestRedisHook(t *testing.T) {
+	recorder := recordSpans(t)
+	hook := RedisHook{}
+	ctx := context.Background()
+
+	process := func(cmd redis.Cmder, err error) {
+		spanCtx, _ := hook.BeforeProcess(ctx, cmd)
+		cmd.SetErr(err)
+		hook.AfterProcess(spanCtx, cmd)
+	}
+	process(redis.NewStringCmd(ctx, "get", "token:secret"), redis.Nil)
+	process(redis.NewStatusCmd(ctx, "set", "token:secret", "1"), context.DeadlineExceeded)
+
+	cmds := []redis.Cmder{redis.NewStringCmd(ctx, "get", "a"), redis.NewIntCmd(ctx, "del", "b")}
+	spanCtx, _ := hook.BeforeProcessPipeline(ctx, cmds)
+	cmds[1].SetErr(context.DeadlineExceeded)
+	hook.AfterProcessPipeline(spanCtx, cmds)
+
+	spans := recorder.reset()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want 3", len(spans))
+	}
+	tests := []struct {
+		name      string
+		operation string
+		status    codes.Code
+	}{
+		{"redis get", "get", codes.OK},
+		{"redis set", "set", codes.Unknown},
+		{"redis pipeline", "get del", codes.Unknown},
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name != tt.name || attribute(span, "db.operation") != tt.operation || span.StatusCode != tt.status {
+			t.Errorf("span %d = %q (%q, %v), want %q (%q, %v)", i, span.Name, attribute(span, "db.operation"), span.StatusCode, tt.name, tt.operation, tt.status)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kekim-go/Author/handler"
+)
+
+const extAuthzPath = "/ext-authz"
+
+type extAuthzServer struct {
+	handler *handler.AppTokenHandler
+}
+
+func newExtAuthzServer(handler *handler.AppTokenHandler) *extAuthzServer {
+	return &extAuthzServer{handler: handler}
+}
+
+// check nginx auth_request, Envoy HTTP ext_authz 호환 엔드포인트
+// 원 요청의 경로는 X-Original-URI 헤더(nginx) 또는 /ext-authz 이후의 경로(Envoy path_prefix)에서 추출
+func (e *extAuthzServer) check(w http.ResponseWriter, r *http.Request) {
+	path := r.Header.Get("X-Original-URI")
+	if len(path) == 0 {
+		path = strings.TrimPrefix(r.URL.RequestURI(), extAuthzPath)
+	}
+
+	host := r.Header.Get("X-Forwarded-Host")
+	if len(host) == 0 {
+		host = r.Host
+	}
+
+	headers := make(map[string]string, len(r.Header))
+	for key, values := range r.Header {
+		if len(values) > 0 {
+			headers[strings.ToLower(key)] = values[0]
+		}
+	}
+
+	res := e.handler.ExtAuthorize(r.Context(), handler.ExtAuthzReq{Host: host, Path: path, Headers: headers})
+
+	for key, value := range res.Headers {
+		w.Header().Set(key, value)
+	}
+	w.WriteHeader(res.Status)
+}
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/ratelimit"
+)
+
+func TestExtAuthzCheck(t *testing.T) {
+	c := newTestContext(t)
+	c.LocalLimiter = ratelimit.NewLocalLimiter()
+	e := newExtAuthzServer(handler.NewAppTokenHandler(c))
+
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		status  int
+		code    grpc_author.ApiAuthRes_Code
+	}{
+		{"no key", "/ext-authz/svc/items", nil, http.StatusUnauthorized, grpc_author.ApiAuthRes_PARAMETER_EXCEPTION},
+		{"envoy path prefix", "/ext-authz/svc/items?serviceKey=key", nil, http.StatusForbidden, grpc_author.ApiAuthRes_UNREGISTERED_SERVICE},
+		{"nginx original uri", "/ext-authz", map[string]string{"X-Original-URI": "/svc/items", "X-Api-Key": "key"}, http.StatusForbidden, grpc_author.ApiAuthRes_UNREGISTERED_SERVICE},
+		{"nginx original uri without key", "/ext-authz?serviceKey=key", map[string]string{"X-Original-URI": "/svc/items"}, http.StatusUnauthorized, grpc_author.ApiAuthRes_PARAMETER_EXCEPTION},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		for key, value := range tt.headers {
+			r.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		e.check(w, r)
+
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.status)
+		}
+		if code := w.Header().Get(handler.ExtAuthzCodeHeader); code != tt.code.String() {
+			t.Errorf("%s: %s = %q, want %s", tt.name, handler.ExtAuthzCodeHeader, code, tt.code)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package web
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	server "github.com/kekim-go/Author/grpc"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const maxRequestBodySize = 1 << 20
+
+var (
+	marshaler   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// gatewayServer gRPC 서비스의 HTTP/JSON 게이트웨이
+// 요청/응답 본문은 proto 메시지의 JSON 표현(snake_case 필드명)을 사용하며, 결과 코드는 gRPC와 같이 본문의 code로 전달
+type gatewayServer struct {
+	ctx      *ctx.Context
+	services *server.Services
+}
+
+func newGatewayServer(c *ctx.Context, services *server.Services) *gatewayServer {
+	return &gatewayServer{ctx: c, services: services}
+}
+
+// POST /v1/auth/login
+func (g *gatewayServer) login(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.LoginReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.Auth.Login(c, req)
+	})
+}
+
+// POST /v1/auth/mfa
+func (g *gatewayServer) verifyMfa(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.VerifyMfaReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.Auth.VerifyMfa(c, req)
+	})
+}
+
+// POST /v1/auth/refresh
+func (g *gatewayServer) refresh(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.RefreshTokenReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.Auth.Refresh(c, req)
+	})
+}
+
+// POST /v1/users
+func (g *gatewayServer) signup(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.UserReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.User.Signup(c, req)
+	})
+}
+
+// POST /v1/users/password-reset
+func (g *gatewayServer) requestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.PasswordResetReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.User.RequestPasswordReset(c, req)
+	})
+}
+
+// POST /v1/users/password-reset/confirm
+func (g *gatewayServer) confirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.ConfirmPasswordResetReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.User.ConfirmPasswordReset(c, req)
+	})
+}
+
+// POST /v1/api-auth
+func (g *gatewayServer) apiAuth(w http.ResponseWriter, r *http.Request) {
+	req := &grpc_author.ApiAuthReq{}
+	g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+		return g.services.ApiAuth.Auth(c, req)
+	})
+}
+
+// POST /v1/apps, GET /v1/apps, GET /v1/apps/{app_id}, PUT /v1/apps/{app_id}, DELETE /v1/apps/{app_id}
+// PUT /v1/apps/{app_id}/status, POST /v1/apps/{app_id}/restore
+// 목록과 상세 조회 조건은 쿼리 파라미터(page, per_page, name_space, include_deleted)로 전달
+// gRPC AppManager는 내부망 전용이므로, HTTP에서는 관리자 JWT(Authorization: Bearer)를 요구
+func (g *gatewayServer) apps(w http.ResponseWriter, r *http.Request) {
+	if httpStatus, msg := g.checkAdmin(r.Context(), bearerToken(r)); httpStatus != http.StatusOK {
+		if httpStatus == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		writeJSON(w, httpStatus, map[string]interface{}{"code": httpStatus, "message": msg})
+		return
+	}
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/apps"), "/")
+	req := &grpc_author.AppReq{}
+
+	switch {
+	case len(id) == 0 && r.Method == http.MethodPost:
+		g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+			return g.services.AppManager.Create(c, req)
+		})
+	case len(id) == 0 && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		listReq := &grpc_author.ListAppsReq{
+			Page:           queryUint(query, "page"),
+			PerPage:        queryUint(query, "per_page"),
+			NameSpace:      query.Get("name_space"),
+			IncludeDeleted: queryBool(query, "include_deleted"),
+		}
+		g.unary(w, r, http.MethodGet, listReq, func(c context.Context) (proto.Message, error) {
+			return g.services.AppManager.List(c, listReq)
+		})
+	case strings.HasSuffix(id, "/status") && r.Method == http.MethodPut:
+		appId, err := strconv.ParseUint(strings.TrimSuffix(id, "/status"), 10, 32)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"code": http.StatusNotFound, "message": "not found"})
+			return
+		}
+
+		statusReq := &grpc_author.ChangeAppStatusReq{}
+		g.unary(w, r, http.MethodPut, statusReq, func(c context.Context) (proto.Message, error) {
+			statusReq.AppId = uint32(appId)
+			return g.services.AppManager.ChangeStatus(c, statusReq)
+		})
+	case strings.HasSuffix(id, "/restore") && r.Method == http.MethodPost:
+		appId, err := strconv.ParseUint(strings.TrimSuffix(id, "/restore"), 10, 32)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"code": http.StatusNotFound, "message": "not found"})
+			return
+		}
+
+		g.unary(w, r, http.MethodPost, req, func(c context.Context) (proto.Message, error) {
+			req.AppId = uint32(appId)
+			return g.services.AppManager.Restore(c, req)
+		})
+	case len(id) > 0 && (r.Method == http.MethodGet || r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		appId, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"code": http.StatusNotFound, "message": "not found"})
+			return
+		}
+
+		g.unary(w, r, r.Method, req, func(c context.Context) (proto.Message, error) {
+			req.AppId = uint32(appId)
+			switch r.Method {
+			case http.MethodGet:
+				return g.services.AppManager.Get(c, &grpc_author.GetAppReq{
+					AppId:          req.AppId,
+					IncludeDeleted: queryBool(r.URL.Query(), "include_deleted"),
+				})
+			case http.MethodDelete:
+				return g.services.AppManager.Destroy(c, req)
+			}
+			return g.services.AppManager.Update(c, req)
+		})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// 잘못된 값은 지정하지 않은 것으로 처리
+func queryUint(query url.Values, key string) uint32 {
+	value, _ := strconv.ParseUint(query.Get(key), 10, 32)
+	return uint32(value)
+}
+
+func queryBool(query url.Values, key string) bool {
+	value, _ := strconv.ParseBool(query.Get(key))
+	return value
+}
+
+// 요청 본문을 req로 변환하여 call을 실행하고 응답 메시지를 JSON으로 작성
+// call이 반환한 오류는 gRPC 상태 코드에 대응하는 HTTP 상태로 변환
+func (g *gatewayServer) unary(w http.ResponseWriter, r *http.Request, method string, req proto.Message, call func(context.Context) (proto.Message, error)) {
+	if r.Method != method {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		writeJSON(w, http.StatusRequestEntityTooLarge, map[string]interface{}{"code": http.StatusRequestEntityTooLarge, "message": err.Error()})
+		return
+	}
+	if len(body) > 0 {
+		if err := unmarshaler.Unmarshal(body, req); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": http.StatusBadRequest, "message": err.Error()})
+			return
+		}
+	}
+
+	res, err := call(incomingContext(r))
+	if err != nil {
+		logging.FromContext(r.Context(), g.ctx.Logger).WithFields(logrus.Fields{
+			"module": "gatewayServer",
+			"path":   r.URL.Path,
+		}).Info(err)
+
+		// gRPC 상태 오류가 아닌 내부 오류의 메시지는 노출하지 않음
+		st, ok := status.FromError(err)
+		if !ok {
+			st = status.New(codes.Internal, "internal exception")
+		}
+		writeJSON(w, httpStatusFromCode(st.Code()), map[string]interface{}{"code": int(st.Code()), "message": st.Message()})
+		return
+	}
+
+	out, err := marshaler.Marshal(res)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"code": http.StatusInternalServerError, "message": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(out)
+}
+
+// HTTP 요청의 클라이언트 주소와 user-agent를 gRPC 요청과 같은 형태로 전달 (로그인 이력 등에서 사용)
+func incomingContext(r *http.Request) context.Context {
+	c := metadata.NewIncomingContext(r.Context(), metadata.Pairs("user-agent", r.UserAgent()))
+
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		c = peer.NewContext(c, &peer.Peer{Addr: addr})
+	}
+
+	return c
+}
+
+// checkAdmin 관리자 JWT 확인, HTTP 상태 코드와 메시지 반환
+func (g *gatewayServer) checkAdmin(c context.Context, jwt string) (int, string) {
+	logger := logging.FromContext(c, g.ctx.Logger)
+
+	user, err := model.FindUserByJwt(c, g.ctx.Orm, jwt)
+	if err != nil {
+		logger.Info(err.Error())
+		return http.StatusUnauthorized, "invalid token"
+	}
+
+	isAdmin, err := model.HasRole(c, g.ctx.Orm, user.Id, constant.RoleAdmin)
+	if err != nil {
+		logger.Info(err.Error())
+		return http.StatusInternalServerError, "internal exception"
+	}
+	if !isAdmin {
+		return http.StatusForbidden, "permission denied"
+	}
+
+	return http.StatusOK, ""
+}
+
+// grpc-gateway와 같은 gRPC 상태 코드 -> HTTP 상태 코드 대응
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,191 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// createTestMember JWT로 인증할 수 있는 회원 등록, admin이면 관리자 역할 부여
+func createTestMember(t *testing.T, c *ctx.Context, loginId string, admin bool) string {
+	t.Helper()
+
+	user := &model.User{LoginId: loginId, Email: loginId + "@example.com", Name: loginId}
+	if _, err := c.Orm.Insert(user); err != nil {
+		t.Fatal(err)
+	}
+	jwt := loginId + "-jwt"
+	expiredAt := time.Now().Add(time.Hour)
+	if _, err := c.Orm.Insert(&model.UserToken{UserId: user.Id, Jwt: jwt, RefreshToken: loginId + "-refresh", JwtExpiredAt: &expiredAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	if admin {
+		role := &model.Role{Name: constant.RoleAdmin}
+		if _, err := c.Orm.Insert(role); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Orm.Insert(&model.UserRole{UserId: user.Id, RoleId: role.Id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return jwt
+}
+
+// 실제 서버와 같은 경로 등록으로 요청 처리
+func newTestMux(c *ctx.Context) *routeMux {
+	mux := &routeMux{ServeMux: http.NewServeMux()}
+	New(c, context.Background()).apiRoutes(mux)
+
+	return mux
+}
+
+func serveJSON(t *testing.T, handler http.Handler, method string, target string, jwt string, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if len(jwt) > 0 {
+		r.Header.Set("Authorization", "Bearer "+jwt)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	res := map[string]interface{}{}
+	if w.Body.Len() > 0 {
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q", method, target, w.Body.String())
+		}
+	}
+
+	return w, res
+}
+
+// /v1/apps는 관리자 JWT가 있어야 하고, 경로와 메소드에 따라 AppManager RPC로 연결
+func TestGatewayApps(t *testing.T) {
+	c := newTestContext(t)
+	mux := newTestMux(c)
+	adminJwt := createTestMember(t, c, "admin", true)
+	memberJwt := createTestMember(t, c, "member", false)
+
+	w, _ := serveJSON(t, mux, http.MethodGet, "/v1/apps", "", "")
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Header().Get("WWW-Authenticate"), "invalid_token") {
+		t.Errorf("without jwt: status = %d, WWW-Authenticate = %q", w.Code, w.Header().Get("WWW-Authenticate"))
+	}
+	if w, _ := serveJSON(t, mux, http.MethodGet, "/v1/apps", "unknown", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("unknown jwt: status = %d, want 401", w.Code)
+	}
+	if w, _ := serveJSON(t, mux, http.MethodGet, "/v1/apps", memberJwt, ""); w.Code != http.StatusForbidden {
+		t.Errorf("member jwt: status = %d, want 403", w.Code)
+	}
+
+	w, res := serveJSON(t, mux, http.MethodPost, "/v1/apps", adminJwt, `{"app_id": 1, "name_space": "svc"}`)
+	if w.Code != http.StatusOK || res["status"] != grpc_author.AppRes_OK.String() {
+		t.Fatalf("create: status = %d, body = %v", w.Code, res)
+	}
+
+	w, res = serveJSON(t, mux, http.MethodGet, "/v1/apps/1", adminJwt, "")
+	if app, _ := res["app"].(map[string]interface{}); w.Code != http.StatusOK || app["name_space"] != "svc" {
+		t.Errorf("get: status = %d, body = %v", w.Code, res)
+	}
+
+	w, res = serveJSON(t, mux, http.MethodGet, "/v1/apps?name_space=sv", adminJwt, "")
+	if apps, _ := res["apps"].([]interface{}); w.Code != http.StatusOK || len(apps) != 1 {
+		t.Errorf("list: status = %d, body = %v", w.Code, res)
+	}
+
+	// version 없이 상태 변경 요청
+	w, res = serveJSON(t, mux, http.MethodPut, "/v1/apps/1/status", adminJwt, `{"status": "SUSPENDED"}`)
+	if w.Code != http.StatusOK || res["status"] != grpc_author.AppRes_INVALID_ARGUMENT.String() {
+		t.Errorf("change status without version: status = %d, body = %v", w.Code, res)
+	}
+
+	routes := []struct {
+		method string
+		target string
+		status int
+	}{
+		{http.MethodGet, "/v1/apps/abc", http.StatusNotFound},
+		{http.MethodPut, "/v1/apps/abc/status", http.StatusNotFound},
+		{http.MethodPatch, "/v1/apps", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/v1/apps/1/restore", http.StatusNotFound},
+	}
+	for _, route := range routes {
+		if w, _ := serveJSON(t, mux, route.method, route.target, adminJwt, ""); w.Code != route.status {
+			t.Errorf("%s %s: status = %d, want %d", route.method, route.target, w.Code, route.status)
+		}
+	}
+}
+
+func TestGatewayUnary(t *testing.T) {
+	c := newTestContext(t)
+	mux := newTestMux(c)
+
+	if w, _ := serveJSON(t, mux, http.MethodGet, "/v1/auth/login", "", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET login: status = %d, want 405", w.Code)
+	}
+	if w, _ := serveJSON(t, mux, http.MethodPost, "/v1/auth/login", "", "{"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: status = %d, want 400", w.Code)
+	}
+
+	// 결과 코드는 HTTP 상태가 아닌 본문의 code로 전달
+	w, res := serveJSON(t, mux, http.MethodPost, "/v1/auth/login", "", `{"login_id": "nobody", "password": "password1", "unknown": 1}`)
+	if w.Code != http.StatusOK || res["code"] != grpc_author.AuthResult_NOT_REGISTERED.String() || w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("login: status = %d, body = %v", w.Code, res)
+	}
+}
+
+// RPC가 반환한 gRPC 상태 오류는 대응하는 HTTP 상태로, 그 외 오류는 메시지 없이 500으로 응답
+func TestGatewayErrorMapping(t *testing.T) {
+	c := newTestContext(t)
+	g := newGatewayServer(c, nil)
+
+	tests := []struct {
+		err     error
+		status  int
+		code    codes.Code
+		message string
+	}{
+		{status.Error(codes.InvalidArgument, "bad"), http.StatusBadRequest, codes.InvalidArgument, "bad"},
+		{status.Error(codes.NotFound, "missing"), http.StatusNotFound, codes.NotFound, "missing"},
+		{status.Error(codes.AlreadyExists, "exists"), http.StatusConflict, codes.AlreadyExists, "exists"},
+		{status.Error(codes.PermissionDenied, "denied"), http.StatusForbidden, codes.PermissionDenied, "denied"},
+		{status.Error(codes.Unauthenticated, "login"), http.StatusUnauthorized, codes.Unauthenticated, "login"},
+		{status.Error(codes.ResourceExhausted, "slow down"), http.StatusTooManyRequests, codes.ResourceExhausted, "slow down"},
+		{status.Error(codes.Unavailable, "down"), http.StatusServiceUnavailable, codes.Unavailable, "down"},
+		{status.Error(codes.DeadlineExceeded, "late"), http.StatusGatewayTimeout, codes.DeadlineExceeded, "late"},
+		{errors.New("dial tcp 10.0.0.1:3306: connection refused"), http.StatusInternalServerError, codes.Internal, "internal exception"},
+	}
+	for _, tt := range tests {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			g.unary(w, r, http.MethodPost, &grpc_author.LoginReq{}, func(context.Context) (proto.Message, error) {
+				return nil, tt.err
+			})
+		})
+
+		w, res := serveJSON(t, handler, http.MethodPost, "/v1/test", "", "")
+		if w.Code != tt.status || res["code"] != float64(tt.code) || res["message"] != tt.message {
+			t.Errorf("%v: status = %d, body = %v, want %d %d %q", tt.err, w.Code, res, tt.status, tt.code, tt.message)
+		}
+	}
+
+	if status := httpStatusFromCode(codes.Canceled); status != 499 {
+		t.Errorf("canceled = %d, want 499", status)
+	}
+	if status := httpStatusFromCode(codes.DataLoss); status != http.StatusInternalServerError {
+		t.Errorf("data loss = %d, want 500", status)
+	}
+}
@@ -0,0 +1,270 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	server "github.com/kekim-go/Author/grpc"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+	"github.com/sirupsen/logrus"
+)
+
+type oauthServer struct {
+	handler  *handler.OAuthHandler
+	services *server.Services // authorize 로그인 화면에서 AuthService의 로그인, 2단계 인증 처리를 사용
+}
+
+func newOAuthServer(handler *handler.OAuthHandler, services *server.Services) *oauthServer {
+	return &oauthServer{handler: handler, services: services}
+}
+
+// authorize authorization code + PKCE 발급
+// Authorization: Bearer 헤더로 회원 JWT를 전달한 경우(자체 앱) 바로 발급하고,
+// 그 외에는 로그인 화면에서 발급한 세션 쿠키로 회원을 확인한 후 동의 화면에서 승인해야 발급
+func (o *oauthServer) authorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseForm()
+
+	req := newAuthorizeReq(r.Form)
+
+	// redirect_uri가 확인되기 전의 오류는 redirect 하지 않음
+	client, err := o.handler.FindAuthorizeClient(r.Context(), req.ClientId, req.RedirectUri)
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	scope, err := o.handler.ValidateAuthorize(client, req)
+	if err != nil {
+		o.redirectError(w, r, req, err)
+		return
+	}
+
+	if jwt := bearerToken(r); len(jwt) > 0 {
+		user, err := model.FindUserByJwt(r.Context(), o.handler.Ctx.Orm, jwt)
+		if err != nil {
+			logging.FromContext(r.Context(), o.handler.Ctx.Logger).Debug(err.Error())
+			o.redirectError(w, r, req, &handler.OAuthError{Code: oauthAccessDenied, Description: "user authentication required"})
+			return
+		}
+		o.issueCode(w, r, client, user, req)
+		return
+	}
+
+	action := ""
+	if r.Method == http.MethodPost {
+		if !checkCsrfToken(r) {
+			http.Error(w, "invalid csrf token", http.StatusForbidden)
+			return
+		}
+		action = r.PostForm.Get("action")
+	}
+
+	switch action {
+	case actionLogin:
+		o.login(w, r, client, req)
+		return
+	case actionMfa:
+		o.verifyMfa(w, r, client, req)
+		return
+	}
+
+	user := o.sessionUser(r)
+	if user == nil {
+		o.render(w, r, pageData{Page: pageLogin, Client: client, Req: req})
+		return
+	}
+
+	switch action {
+	case "":
+		o.render(w, r, pageData{Page: pageConsent, Client: client, Req: req, User: user, Scopes: strings.Fields(scope)})
+	case actionApprove:
+		o.issueCode(w, r, client, user, req)
+	case actionDeny:
+		o.redirectError(w, r, req, &handler.OAuthError{Code: oauthAccessDenied, Description: "user denied the request"})
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+	}
+}
+
+func (o *oauthServer) issueCode(w http.ResponseWriter, r *http.Request, client *model.OAuthClient, user *model.User, req handler.AuthorizeReq) {
+	code, err := o.handler.Authorize(r.Context(), client, user, req)
+	if err != nil {
+		o.redirectError(w, r, req, err)
+		return
+	}
+
+	redirect(w, r, req.RedirectUri, url.Values{"code": {code}, "state": {req.State}})
+}
+
+// redirect_uri로 RFC 6749 4.1.2.1 형식의 오류 전달
+func (o *oauthServer) redirectError(w http.ResponseWriter, r *http.Request, req handler.AuthorizeReq, err error) {
+	oauthErr, ok := err.(*handler.OAuthError)
+	if !ok {
+		o.logError(r.Context(), "authorize", err)
+		oauthErr = &handler.OAuthError{Code: handler.OAuthServerError}
+	}
+
+	redirect(w, r, req.RedirectUri, url.Values{
+		"error":             {oauthErr.Code},
+		"error_description": {oauthErr.Description},
+		"state":             {req.State},
+	})
+}
+
+func (o *oauthServer) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	client, err := o.authenticateClient(r)
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	res, err := o.handler.Token(r.Context(), client, handler.TokenReq{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Scope:        r.PostForm.Get("scope"),
+		Code:         r.PostForm.Get("code"),
+		RedirectUri:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+	})
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeJSON(w, http.StatusOK, res)
+}
+
+// introspect RFC 7662, confidential 클라이언트(리소스 서버)만 사용 가능
+func (o *oauthServer) introspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	client, err := o.authenticateClient(r)
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+	if !client.IsConfidential() {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"error":             handler.OAuthInvalidClient,
+			"error_description": "client authentication required",
+		})
+		return
+	}
+
+	res, err := o.handler.Introspect(r.Context(), r.PostForm.Get("token"))
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// revoke RFC 7009
+func (o *oauthServer) revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	client, err := o.authenticateClient(r)
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	if err := o.handler.Revoke(r.Context(), client, r.PostForm.Get("token")); err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HTTP Basic 또는 form 파라미터(client_id, client_secret)로 클라이언트 인증
+func (o *oauthServer) authenticateClient(r *http.Request) (*model.OAuthClient, error) {
+	r.ParseForm()
+
+	clientId, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientId, _ = url.QueryUnescape(clientId)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientId = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+
+	return o.handler.AuthenticateClient(r.Context(), clientId, clientSecret)
+}
+
+func (o *oauthServer) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	oauthErr, ok := err.(*handler.OAuthError)
+	if !ok {
+		o.logError(r.Context(), "oauth", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": handler.OAuthServerError})
+		return
+	}
+
+	if oauthErr.Code == handler.OAuthInvalidToken || oauthErr.Code == handler.OAuthInsufficientScope {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="%s"`, oauthErr.Code))
+	} else if oauthErr.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	writeJSON(w, oauthErr.Status, map[string]string{
+		"error":             oauthErr.Code,
+		"error_description": oauthErr.Description,
+	})
+}
+
+func (o *oauthServer) logError(ctx context.Context, function string, err error) {
+	logging.FromContext(ctx, o.handler.Ctx.Logger).WithFields(logrus.Fields{
+		"module":   "oauthServer",
+		"function": function,
+	}).Info(err)
+}
+
+func redirect(w http.ResponseWriter, r *http.Request, redirectUri string, params url.Values) {
+	u, err := url.Parse(redirectUri)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		if len(values) > 0 && len(values[0]) > 0 {
+			query.Set(key, values[0])
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func bearerToken(r *http.Request) string {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		return strings.TrimSpace(authorization[7:])
+	}
+
+	return ""
+}
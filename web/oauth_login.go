@@ -0,0 +1,258 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	grpc_author "github.com/kekim-go/Author/gen/proto/author"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/model"
+)
+
+const (
+	oauthAccessDenied = "access_denied"
+
+	// authorize 화면의 form action 값
+	actionLogin   = "login"
+	actionMfa     = "mfa"
+	actionApprove = "approve"
+	actionDeny    = "deny"
+
+	pageLogin   = "login"
+	pageMfa     = "mfa"
+	pageConsent = "consent"
+
+	// 세션 쿠키는 로그인으로 발급한 JWT, authorize 경로에서만 사용
+	sessionCookie = "author_session"
+	csrfCookie    = "author_csrf"
+	cookiePath    = "/oauth"
+)
+
+func newAuthorizeReq(form url.Values) handler.AuthorizeReq {
+	return handler.AuthorizeReq{
+		ResponseType:        form.Get("response_type"),
+		ClientId:            form.Get("client_id"),
+		RedirectUri:         form.Get("redirect_uri"),
+		Scope:               form.Get("scope"),
+		State:               form.Get("state"),
+		CodeChallenge:       form.Get("code_challenge"),
+		CodeChallengeMethod: form.Get("code_challenge_method"),
+		Nonce:               form.Get("nonce"),
+	}
+}
+
+// 로그인, 동의 화면의 form에서 authorize 요청 파라미터를 그대로 다시 전달
+func authorizeParams(req handler.AuthorizeReq) url.Values {
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"response_type":         req.ResponseType,
+		"client_id":             req.ClientId,
+		"redirect_uri":          req.RedirectUri,
+		"scope":                 req.Scope,
+		"state":                 req.State,
+		"code_challenge":        req.CodeChallenge,
+		"code_challenge_method": req.CodeChallengeMethod,
+		"nonce":                 req.Nonce,
+	} {
+		if len(value) > 0 {
+			params.Set(key, value)
+		}
+	}
+
+	return params
+}
+
+// login 아이디, 비밀번호 확인 후 세션 발급, 2단계 인증 사용 회원은 코드 입력 화면 표시
+func (o *oauthServer) login(w http.ResponseWriter, r *http.Request, client *model.OAuthClient, req handler.AuthorizeReq) {
+	res, err := o.services.Auth.Login(incomingContext(r), &grpc_author.LoginReq{
+		LoginId:  r.PostForm.Get("login_id"),
+		Password: r.PostForm.Get("password"),
+	})
+	if err != nil {
+		o.logError(r.Context(), "login", err)
+		res = &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}
+	}
+
+	switch res.Code {
+	case grpc_author.AuthResult_VALID:
+		o.startSession(w, r, req, res)
+	case grpc_author.AuthResult_MFA_REQUIRED:
+		o.render(w, r, pageData{Page: pageMfa, Client: client, Req: req, MfaToken: res.MfaToken})
+	case grpc_author.AuthResult_INTERNAL_EXCEPTION:
+		o.render(w, r, pageData{Page: pageLogin, Client: client, Req: req, Error: "Sign in is temporarily unavailable."})
+	default:
+		// 회원 여부가 드러나지 않도록 같은 메시지 사용
+		o.render(w, r, pageData{Page: pageLogin, Client: client, Req: req, Error: "Invalid login ID or password."})
+	}
+}
+
+func (o *oauthServer) verifyMfa(w http.ResponseWriter, r *http.Request, client *model.OAuthClient, req handler.AuthorizeReq) {
+	mfaToken := r.PostForm.Get("mfa_token")
+	res, err := o.services.Auth.VerifyMfa(incomingContext(r), &grpc_author.VerifyMfaReq{
+		MfaToken: mfaToken,
+		Code:     r.PostForm.Get("code"),
+	})
+	if err != nil {
+		o.logError(r.Context(), "verifyMfa", err)
+		res = &grpc_author.AuthRes{Code: grpc_author.AuthResult_INTERNAL_EXCEPTION}
+	}
+
+	switch res.Code {
+	case grpc_author.AuthResult_VALID:
+		o.startSession(w, r, req, res)
+	case grpc_author.AuthResult_INVALID_MFA_CODE:
+		o.render(w, r, pageData{Page: pageMfa, Client: client, Req: req, MfaToken: mfaToken, Error: "Invalid verification code."})
+	case grpc_author.AuthResult_MFA_LOCKED:
+		o.render(w, r, pageData{Page: pageLogin, Client: client, Req: req, Error: "Too many failed attempts. Try again later."})
+	default:
+		// 대기 토큰이 만료되었거나 실패 횟수를 초과한 경우 처음부터 다시 로그인
+		o.render(w, r, pageData{Page: pageLogin, Client: client, Req: req, Error: "Sign in again."})
+	}
+}
+
+// startSession 로그인으로 발급된 JWT를 세션 쿠키로 저장하고, 새로고침으로 다시 제출되지 않도록 동의 화면으로 redirect
+func (o *oauthServer) startSession(w http.ResponseWriter, r *http.Request, req handler.AuthorizeReq, res *grpc_author.AuthRes) {
+	expires := time.Now().Add(time.Hour)
+	if res.ExpiresIn != nil {
+		if t, err := ptypes.Timestamp(res.ExpiresIn); err == nil {
+			expires = t
+		}
+	}
+
+	http.SetCookie(w, o.cookie(r, sessionCookie, res.Jwt, expires))
+	http.Redirect(w, r, r.URL.Path+"?"+authorizeParams(req).Encode(), http.StatusSeeOther)
+}
+
+// sessionUser 세션 쿠키의 JWT로 회원 조회, 세션이 없거나 만료된 경우 nil
+func (o *oauthServer) sessionUser(r *http.Request) *model.User {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil || len(cookie.Value) == 0 {
+		return nil
+	}
+
+	user, err := model.FindUserByJwt(r.Context(), o.handler.Ctx.Orm, cookie.Value)
+	if err != nil {
+		logging.FromContext(r.Context(), o.handler.Ctx.Logger).Debug(err.Error())
+		return nil
+	}
+
+	return user
+}
+
+// HTTPS로 요청되었거나 issuer가 https인 경우(TLS 종료 proxy 뒤에서 실행) Secure 쿠키로 발급
+func (o *oauthServer) cookie(r *http.Request, name string, value string, expires time.Time) *http.Cookie {
+	secure := r.TLS != nil
+	if provider := o.handler.Ctx.Oidc; provider != nil && strings.HasPrefix(provider.Issuer(), "https://") {
+		secure = true
+	}
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cookiePath,
+		Expires:  expires,
+		Secure:   secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// csrfToken double submit 방식, 쿠키와 form의 csrf_token 값이 같아야 POST 요청 처리
+func (o *oauthServer) csrfToken(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(csrfCookie); err == nil && len(cookie.Value) > 0 {
+		return cookie.Value
+	}
+
+	b := make([]byte, 32)
+	rand.Read(b)
+	token := fmt.Sprintf("%x", b)
+	http.SetCookie(w, o.cookie(r, csrfCookie, token, time.Time{}))
+
+	return token
+}
+
+func checkCsrfToken(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookie)
+	if err != nil || len(cookie.Value) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.PostForm.Get("csrf_token"))) == 1
+}
+
+type pageData struct {
+	Page      string
+	Client    *model.OAuthClient
+	Req       handler.AuthorizeReq
+	User      *model.User
+	Scopes    []string
+	MfaToken  string
+	Error     string
+	CsrfToken string
+	Params    url.Values
+}
+
+func (o *oauthServer) render(w http.ResponseWriter, r *http.Request, data pageData) {
+	data.CsrfToken = o.csrfToken(w, r)
+	data.Params = authorizeParams(data.Req)
+
+	// 동의 화면을 다른 사이트의 frame에 넣어 클릭을 유도하지 못하도록 차단
+	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+
+	if err := authorizeTemplate.Execute(w, data); err != nil {
+		o.logError(r.Context(), "render", err)
+	}
+}
+
+var authorizeTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if eq .Page "consent"}}Authorize {{or .Client.Name .Client.ClientId}}{{else}}Sign in{{end}}</title>
+<style>
+body { font-family: sans-serif; max-width: 360px; margin: 48px auto; padding: 0 16px; }
+input[type=text], input[type=password] { display: block; width: 100%; box-sizing: border-box; margin: 4px 0 12px; padding: 8px; }
+button { padding: 8px 16px; margin-right: 8px; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+{{if eq .Page "consent"}}
+<h1>Authorize {{or .Client.Name .Client.ClientId}}</h1>
+<p>Signed in as <strong>{{.User.LoginId}}</strong>. {{or .Client.Name .Client.ClientId}} is requesting access to:</p>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>
+{{else}}
+<h1>Sign in to continue to {{or .Client.Name .Client.ClientId}}</h1>
+{{end}}
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<form method="post">
+{{range $key, $values := .Params}}<input type="hidden" name="{{$key}}" value="{{index $values 0}}">
+{{end}}<input type="hidden" name="csrf_token" value="{{.CsrfToken}}">
+{{if eq .Page "login"}}
+<label>Login ID<input type="text" name="login_id" autocomplete="username" required autofocus></label>
+<label>Password<input type="password" name="password" autocomplete="current-password" required></label>
+<button type="submit" name="action" value="login">Sign in</button>
+{{else if eq .Page "mfa"}}
+<input type="hidden" name="mfa_token" value="{{.MfaToken}}">
+<label>Verification code or recovery code<input type="text" name="code" autocomplete="one-time-code" required autofocus></label>
+<button type="submit" name="action" value="mfa">Verify</button>
+{{else}}
+<button type="submit" name="action" value="approve">Allow</button>
+<button type="submit" name="action" value="deny">Deny</button>
+{{end}}
+</form>
+</body>
+</html>
+`))
@@ -0,0 +1,274 @@
+package web
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kekim-go/Author/app/ctx"
+	"github.com/kekim-go/Author/constant"
+	"github.com/kekim-go/Author/database"
+	server "github.com/kekim-go/Author/grpc"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/migration"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/policy"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"xorm.io/xorm"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const testRedirectUri = "https://client.example.com/callback"
+
+func newTestContext(t *testing.T) *ctx.Context {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	entry := logrus.NewEntry(logger)
+
+	orm, err := xorm.NewEngine(ctx.DBSqlite, filepath.Join(t.TempDir(), "author.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { orm.Close() })
+
+	if err := migration.New(orm, entry).Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	hasher, err := policy.NewPasswordHasher(policy.PasswordHashConfig{BcryptCost: bcrypt.MinCost})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ctx.Context{
+		Mode:           constant.ServiceDev,
+		Logger:         entry,
+		Orm:            orm,
+		RedisDB:        database.NewRedisDB(client, 100*time.Millisecond, nil),
+		Config:         &ctx.Config{},
+		PasswordHasher: hasher,
+	}
+}
+
+type authorizeTest struct {
+	t       *testing.T
+	server  *httptest.Server
+	client  *http.Client
+	oauth   *model.OAuthClient
+	cookies []*http.Cookie
+}
+
+func newAuthorizeTest(t *testing.T) *authorizeTest {
+	c := newTestContext(t)
+
+	enc, err := c.PasswordHasher.Hash("password1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Orm.Insert(&model.User{LoginId: "alice", Email: "alice@example.com", Name: "alice", Password: enc}); err != nil {
+		t.Fatal(err)
+	}
+
+	oauthHandler := handler.NewOAuthHandler(c)
+	client := &model.OAuthClient{
+		Name:         "Example",
+		RedirectUris: []string{testRedirectUri},
+		GrantTypes:   []string{model.GrantAuthorizationCode},
+		Scopes:       []string{model.ScopeProfile},
+	}
+	if _, err := oauthHandler.CreateClient(context.Background(), client, false); err != nil {
+		t.Fatal(err)
+	}
+
+	o := newOAuthServer(oauthHandler, server.NewServices(c))
+	s := httptest.NewServer(http.HandlerFunc(o.authorize))
+	t.Cleanup(s.Close)
+
+	return &authorizeTest{
+		t:      t,
+		server: s,
+		oauth:  client,
+		client: &http.Client{
+			// redirect_uri로 이동하지 않고 응답을 확인
+			CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+func (a *authorizeTest) params() url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.oauth.ClientId},
+		"redirect_uri":          {testRedirectUri},
+		"scope":                 {"profile"},
+		"state":                 {"xyz"},
+		"code_challenge":        {"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+func (a *authorizeTest) do(method string, form url.Values) (*http.Response, string) {
+	a.t.Helper()
+
+	req, err := http.NewRequest(method, a.server.URL+"/oauth/authorize?"+a.params().Encode(), strings.NewReader(form.Encode()))
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, cookie := range a.cookies {
+		req.AddCookie(cookie)
+	}
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	for _, cookie := range res.Cookies() {
+		a.setCookie(cookie)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+	return res, string(body)
+}
+
+func (a *authorizeTest) setCookie(cookie *http.Cookie) {
+	for i, c := range a.cookies {
+		if c.Name == cookie.Name {
+			a.cookies[i] = cookie
+			return
+		}
+	}
+	a.cookies = append(a.cookies, cookie)
+}
+
+var csrfPattern = regexp.MustCompile(`name="csrf_token" value="([0-9a-f]+)"`)
+
+func csrfFromPage(t *testing.T, body string) string {
+	t.Helper()
+
+	m := csrfPattern.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("csrf_token not found in page: %s", body)
+	}
+
+	return m[1]
+}
+
+// login 화면에서 로그인 후 동의 화면 표시
+func (a *authorizeTest) login() string {
+	a.t.Helper()
+
+	res, body := a.do(http.MethodGet, nil)
+	if res.StatusCode != http.StatusOK || !strings.Contains(body, `value="login"`) {
+		a.t.Fatalf("GET authorize = %d, want login page: %s", res.StatusCode, body)
+	}
+	csrf := csrfFromPage(a.t, body)
+
+	res, _ = a.do(http.MethodPost, url.Values{"action": {"login"}, "csrf_token": {csrf}, "login_id": {"alice"}, "password": {"password1"}})
+	if res.StatusCode != http.StatusSeeOther {
+		a.t.Fatalf("POST login = %d, want 303", res.StatusCode)
+	}
+
+	res, body = a.do(http.MethodGet, nil)
+	if res.StatusCode != http.StatusOK || !strings.Contains(body, `value="approve"`) {
+		a.t.Fatalf("GET authorize after login = %d, want consent page: %s", res.StatusCode, body)
+	}
+
+	return csrfFromPage(a.t, body)
+}
+
+func TestAuthorizeApprove(t *testing.T) {
+	a := newAuthorizeTest(t)
+	csrf := a.login()
+
+	res, _ := a.do(http.MethodPost, url.Values{"action": {"approve"}, "csrf_token": {csrf}})
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("approve = %d, want 302", res.StatusCode)
+	}
+
+	location, err := res.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(location.String(), testRedirectUri) || len(location.Query().Get("code")) == 0 || location.Query().Get("state") != "xyz" {
+		t.Errorf("approve redirect = %s", location)
+	}
+}
+
+func TestAuthorizeDeny(t *testing.T) {
+	a := newAuthorizeTest(t)
+	csrf := a.login()
+
+	res, _ := a.do(http.MethodPost, url.Values{"action": {"deny"}, "csrf_token": {csrf}})
+	location, err := res.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location.Query().Get("error") != oauthAccessDenied || len(location.Query().Get("code")) > 0 {
+		t.Errorf("deny redirect = %s", location)
+	}
+}
+
+func TestAuthorizeInvalidLogin(t *testing.T) {
+	a := newAuthorizeTest(t)
+
+	_, body := a.do(http.MethodGet, nil)
+	res, body := a.do(http.MethodPost, url.Values{"action": {"login"}, "csrf_token": {csrfFromPage(t, body)}, "login_id": {"alice"}, "password": {"wrong"}})
+	if res.StatusCode != http.StatusOK || !strings.Contains(body, "Invalid login ID or password.") {
+		t.Errorf("invalid login = %d: %s", res.StatusCode, body)
+	}
+	for _, cookie := range a.cookies {
+		if cookie.Name == sessionCookie {
+			t.Error("session cookie must not be issued on failed login")
+		}
+	}
+}
+
+// CSRF 토큰 없이 제출된 승인 요청은 처리하지 않음
+func TestAuthorizeCsrf(t *testing.T) {
+	a := newAuthorizeTest(t)
+	a.login()
+
+	for _, csrf := range []string{"", "0000"} {
+		res, _ := a.do(http.MethodPost, url.Values{"action": {"approve"}, "csrf_token": {csrf}})
+		if res.StatusCode != http.StatusForbidden {
+			t.Errorf("approve with csrf %q = %d, want 403", csrf, res.StatusCode)
+		}
+	}
+}
+
+// 등록되지 않은 redirect_uri는 redirect 하지 않고 오류 응답
+func TestAuthorizeUnregisteredRedirectUri(t *testing.T) {
+	a := newAuthorizeTest(t)
+
+	params := a.params()
+	params.Set("redirect_uri", "https://evil.example.com/callback")
+	res, err := a.client.Get(a.server.URL + "/oauth/authorize?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", res.StatusCode)
+	}
+}
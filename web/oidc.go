@@ -0,0 +1,60 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/model"
+	"github.com/kekim-go/Author/oidc"
+)
+
+// discovery OpenID Connect Discovery 1.0 설정 문서
+func (o *oauthServer) discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := o.handler.Ctx.Oidc.Issuer()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"userinfo_endpoint":                     issuer + "/oauth/userinfo",
+		"jwks_uri":                              issuer + "/.well-known/jwks.json",
+		"introspection_endpoint":                issuer + "/oauth/introspect",
+		"revocation_endpoint":                   issuer + "/oauth/revoke",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{oidc.SigningAlg},
+		"scopes_supported":                      []string{model.ScopeOpenId, model.ScopeProfile, model.ScopeEmail},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"grant_types_supported": []string{
+			model.GrantAuthorizationCode, model.GrantClientCredentials, model.GrantRefreshToken,
+		},
+		"code_challenge_methods_supported": []string{handler.PkceMethodS256, handler.PkceMethodPlain},
+		"claims_supported":                 []string{"iss", "sub", "aud", "exp", "iat", "nonce", "name", "preferred_username", "email"},
+	})
+}
+
+func (o *oauthServer) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, o.handler.Ctx.Oidc.JWKS())
+}
+
+// userinfo OIDC UserInfo, Authorization: Bearer 헤더 또는 access_token 파라미터 사용
+func (o *oauthServer) userinfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	accessToken := bearerToken(r)
+	if len(accessToken) == 0 && r.Method == http.MethodPost {
+		r.ParseForm()
+		accessToken = r.PostForm.Get("access_token")
+	}
+
+	info, err := o.handler.UserInfo(r.Context(), accessToken)
+	if err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/kekim-go/Author/app/ctx"
+	server "github.com/kekim-go/Author/grpc"
+	"github.com/kekim-go/Author/handler"
+	"github.com/kekim-go/Author/logging"
+	"github.com/kekim-go/Author/metrics"
+)
+
+// Server HTTP 엔드포인트(OAuth2, gRPC 서비스의 HTTP/JSON 게이트웨이 등) 제공
+type Server struct {
+	ctx        *ctx.Context
+	context    context.Context
+	httpServer *http.Server
+	name       string
+	routes     func(mux *routeMux)
+}
+
+// routeMux 등록된 경로별로 처리 시간 지표를 기록하는 ServeMux
+type routeMux struct {
+	*http.ServeMux
+}
+
+func (m *routeMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.ServeMux.Handle(pattern, metrics.InstrumentHandler(pattern, http.HandlerFunc(handler)))
+}
+
+// New constructor
+func New(c *ctx.Context, context context.Context) *Server {
+	s := new(Server)
+	s.ctx = c
+	s.context = context
+	s.name = "HTTP"
+	s.routes = s.apiRoutes
+
+	return s
+}
+
+// NewAdmin 운영용 엔드포인트(/metrics) 서버, 외부에 노출하지 않는 포트에서 실행
+func NewAdmin(c *ctx.Context, context context.Context) *Server {
+	s := new(Server)
+	s.ctx = c
+	s.context = context
+	s.name = "admin HTTP"
+	s.routes = s.adminRoutes
+
+	return s
+}
+
+func (s *Server) Run(address string) error {
+	mux := &routeMux{ServeMux: http.NewServeMux()}
+	s.routes(mux)
+
+	s.httpServer = &http.Server{Addr: address, Handler: logging.Middleware(s.ctx.Logger, mux)}
+
+	// ListenAndServe는 Shutdown 호출 즉시 반환되므로, 처리 중인 요청이 끝날 때까지 done으로 대기
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-s.context.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ctx.Config.ServerConfig.GetShutdownTimeout())
+		defer cancel()
+		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+			s.ctx.Logger.Warn(s.name+" graceful shutdown failed: ", err)
+			s.httpServer.Close()
+		}
+	}()
+
+	s.ctx.Logger.Info("start "+s.name+" server at ", address)
+	if err := s.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	<-done
+
+	return nil
+}
+
+func (s *Server) adminRoutes(mux *routeMux) {
+	mux.Handle("/metrics", metrics.Handler())
+}
+
+func (s *Server) apiRoutes(mux *routeMux) {
+	services := server.NewServices(s.ctx)
+
+	oauth := newOAuthServer(handler.NewOAuthHandler(s.ctx), services)
+	mux.HandleFunc("/oauth/authorize", oauth.authorize)
+	mux.HandleFunc("/oauth/token", oauth.token)
+	mux.HandleFunc("/oauth/introspect", oauth.introspect)
+	mux.HandleFunc("/oauth/revoke", oauth.revoke)
+	mux.HandleFunc("/oauth/userinfo", oauth.userinfo)
+	mux.HandleFunc("/.well-known/openid-configuration", oauth.discovery)
+	mux.HandleFunc("/.well-known/jwks.json", oauth.jwks)
+
+	extAuthz := newExtAuthzServer(handler.NewAppTokenHandler(s.ctx))
+	mux.HandleFunc(extAuthzPath, extAuthz.check)
+	mux.HandleFunc(extAuthzPath+"/", extAuthz.check)
+
+	gateway := newGatewayServer(s.ctx, services)
+	mux.HandleFunc("/v1/auth/login", gateway.login)
+	mux.HandleFunc("/v1/auth/mfa", gateway.verifyMfa)
+	mux.HandleFunc("/v1/auth/refresh", gateway.refresh)
+	mux.HandleFunc("/v1/users", gateway.signup)
+	mux.HandleFunc("/v1/users/password-reset", gateway.requestPasswordReset)
+	mux.HandleFunc("/v1/users/password-reset/confirm", gateway.confirmPasswordReset)
+	mux.HandleFunc("/v1/api-auth", gateway.apiAuth)
+	mux.HandleFunc("/v1/apps", gateway.apps)
+	mux.HandleFunc("/v1/apps/", gateway.apps)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}